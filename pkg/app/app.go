@@ -42,6 +42,7 @@ var (
 	isInternalURL      func(string) bool
 	appUpdateAvailable bool
 	lastURLVisited     *url.URL
+	navigationID       int
 	resizeTimer        *time.Timer
 )
 
@@ -110,6 +111,7 @@ func RunWhenOnBrowser() {
 	}()
 
 	rootPrefix = Getenv("GOAPP_ROOT_PREFIX")
+	isHashRouting = Getenv("GOAPP_HASH_ROUTING") == "true"
 	isInternalURL = internalURLChecker()
 	staticResourcesResolver := newClientStaticResourceResolver(Getenv("GOAPP_STATIC_RESOURCES_URL"))
 
@@ -119,12 +121,22 @@ func RunWhenOnBrowser() {
 		SessionStorage:         newJSStorage("sessionStorage"),
 		StaticResourceResolver: staticResourcesResolver,
 		ActionHandlers:         actionHandlers,
+		Hydrate:                Getenv("GOAPP_HYDRATE") == "true",
+		BasePath:               Getenv("GOAPP_BASE_PATH"),
+	}
+	disp.Page = browserPage{
+		resolveStaticResource: staticResourcesResolver,
+		basePath:              disp.BasePath,
 	}
-	disp.Page = browserPage{resolveStaticResource: staticResourcesResolver}
 	disp.Body = newClientBody(&disp)
 	disp.init()
 	defer disp.Close()
 
+	var hydratedStates map[string]json.RawMessage
+	if readJSONScript(hydratedStatesID, &hydratedStates) {
+		disp.states.seed(hydratedStates)
+	}
+
 	window.setBody(disp.Body)
 
 	onAchorClick := FuncOf(onAchorClick(&disp))
@@ -153,10 +165,49 @@ func RunWhenOnBrowser() {
 	closeAppOrientationChange := Window().AddEventListener("orientationchange", onAppOrientationChange)
 	defer closeAppOrientationChange()
 
-	performNavigate(&disp, Window().URL(), false)
+	preloadedLoaderResult = readPreloadedLoaderResult()
+	initHistory()
+
+	loadURL(&disp, toAppURL(Window().URL()))
+
+	if data, ok := readPreRenderedShareTargetData(); ok {
+		disp.shareTarget(data)
+	}
+
+	onFileLaunch := FuncOf(onFileLaunch(&disp))
+	defer onFileLaunch.Release()
+	if launchQueue := Window().Get("launchQueue"); launchQueue.Truthy() {
+		launchQueue.Call("setConsumer", onFileLaunch)
+	}
+
 	disp.start(context.Background())
 }
 
+// loadURL navigates to the URL that the app is loaded with. The URL is set as
+// the last visited one before the navigation middlewares are executed, in
+// order to have the next navigations compared to it when the load is canceled
+// or redirected.
+func loadURL(d Dispatcher, u *url.URL) {
+	lastURLVisited = u
+
+	to, ok := routes.guardNav(d.Context(), d.getBasePath(), nil, u)
+	if !ok {
+		return
+	}
+
+	switch {
+	case to == u:
+		performNavigate(d, u, false)
+
+	case isExternalNavigation(to):
+		navigateExternal(to)
+
+	default:
+		Window().replaceHistory(to)
+		performNavigate(d, to, false)
+	}
+}
+
 func displayLoadError(err any) {
 	loadingLabel := Window().
 		Get("document").
@@ -225,6 +276,12 @@ func newClientBody(d Dispatcher) *htmlBody {
 	return body
 }
 
+// isAppWasmLoader reports whether the given DOM node is the loader displayed
+// while the wasm app is loading. It is kept when the body is hydrated.
+func isAppWasmLoader(v Value) bool {
+	return v.Get("id").String() == "app-wasm-loader"
+}
+
 func onAchorClick(d Dispatcher) func(Value, []Value) any {
 	return func(this Value, args []Value) any {
 		event := Event{Value: args[0]}
@@ -266,6 +323,8 @@ func onAchorClick(d Dispatcher) func(Value, []Value) any {
 
 func onPopState(d Dispatcher) func(this Value, args []Value) any {
 	return func(this Value, args []Value) any {
+		enterHistoryEntry(args[0].Get("state"))
+
 		d.Dispatch(Dispatch{
 			Mode: Update,
 			Function: func(ctx Context) {
@@ -300,13 +359,10 @@ func navigateTo(d Dispatcher, u *url.URL, updateHistory bool) {
 	}
 
 	if isExternalNavigation(u) {
-		if rawurl := u.String(); isInternalURL(rawurl) || isMailTo(u) {
-			Window().Get("location").Set("href", u.String())
-		} else {
-			Window().Call("open", rawurl)
-		}
+		navigateExternal(u)
 		return
 	}
+	u = withBasePath(d.getBasePath(), toAppURL(u))
 
 	luv := lastURLVisited
 
@@ -314,6 +370,29 @@ func navigateTo(d Dispatcher, u *url.URL, updateHistory bool) {
 		return
 	}
 
+	to, ok := routes.guardNav(d.Context(), d.getBasePath(), luv, u)
+	if !ok {
+		// The browser already displays the URL when moving through the
+		// history.
+		if !updateHistory {
+			cancelHistoryMove(luv)
+		}
+		return
+	}
+	if to != u {
+		if isExternalNavigation(to) {
+			navigateExternal(to)
+			return
+		}
+		if !updateHistory {
+			Window().replaceHistory(to)
+		}
+		if to.String() == luv.String() {
+			return
+		}
+		u = to
+	}
+
 	if u.Path == luv.Path && u.Fragment != luv.Fragment {
 		if updateHistory {
 			Window().addHistory(u)
@@ -346,35 +425,100 @@ func performNavigate(d Dispatcher, u *url.URL, updateHistory bool) {
 		return
 	}
 
-	path := strings.TrimPrefix(u.Path, rootPrefix)
-	if path == "" {
-		path = "/"
-	}
-	compo, ok := routes.createComponent(path)
+	path := routePath(d.getBasePath(), u.Path)
+	route, ok := routes.createRoute(path)
 	if !ok {
-		compo = &notFound{}
+		route = routes.createNotFoundRoute(path)
 	}
 
 	disp, ok := d.(ClientDispatcher)
 	if !ok {
 		return
 	}
-	disp.Mount(compo)
 
+	// The URL is updated before the route is displayed in order to have the
+	// page and the route parameters reflecting it while the route is loaded
+	// and while the current one is left.
 	if updateHistory {
 		Window().addHistory(u)
 	} else {
 		lastURLVisited = u
 	}
 
-	disp.Nav(u)
-	if isFragmentNavigation(u) {
+	navigationID++
+	id := navigationID
+	preloaded := preloadedLoaderResult
+	preloadedLoaderResult = nil
+
+	loader, ok := routes.routeLoader(path)
+	switch {
+	case !ok:
+		displayRoute(disp, id, u, route, nil, updateHistory)
+
+	case preloaded != nil && preloaded.Path == path:
+		displayRoute(disp, id, u, route, preloaded, updateHistory)
+
+	default:
+		d.Async(func() {
+			loaded := runLoader(d.Context(), path, loader)
+
+			d.Dispatch(Dispatch{
+				Mode: Update,
+				Function: func(ctx Context) {
+					if id != navigationID {
+						return
+					}
+					displayRoute(disp, id, u, route, &loaded, updateHistory)
+				},
+			})
+		})
+	}
+}
+
+// displayRoute replaces the current route by the given one once the
+// components that are left are ready, unless another navigation started in
+// the meantime.
+func displayRoute(d ClientDispatcher, id int, u *url.URL, route []Composer, loaded *loaderResult, isNewEntry bool) {
+	d.leaveRoute(route, func() {
+		if id != navigationID {
+			return
+		}
+
+		d.mountRoute(route, loaded)
+		d.Nav(u)
+		d.enterRoute()
 		d.Dispatch(Dispatch{
 			Mode: Defer,
 			Function: func(ctx Context) {
-				Window().ScrollToID(u.Fragment)
+				restoreScrollPosition(u, isNewEntry)
 			},
 		})
+	})
+}
+
+// readJSONScript decodes the content of the script element written with
+// jsonScript into the given receiver. It reports whether the element exists
+// and is successfully decoded.
+func readJSONScript(id string, recv any) bool {
+	script := Window().GetElementByID(id)
+	if !script.Truthy() {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(script.Get("textContent").String()), recv); err != nil {
+		Log(errors.New("reading pre-rendered json script failed").
+			WithTag("id", id).
+			Wrap(err))
+		return false
+	}
+	return true
+}
+
+func navigateExternal(u *url.URL) {
+	if rawurl := u.String(); isInternalURL(rawurl) || isMailTo(u) {
+		Window().Get("location").Set("href", rawurl)
+	} else {
+		Window().Call("open", rawurl)
 	}
 }
 
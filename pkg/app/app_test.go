@@ -1,6 +1,7 @@
 package app
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -77,3 +78,31 @@ func TestNewClientStaticResourceResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadURLCanceled(t *testing.T) {
+	testSkipNonWasm(t)
+
+	defer func(luv *url.URL) {
+		lastURLVisited = luv
+	}(lastURLVisited)
+
+	routes.useNavMiddleware(func(ctx Context, from, to *url.URL) NavDecision {
+		if to.Path == "/load-canceled" {
+			return CancelNav()
+		}
+		return AllowNav()
+	})
+
+	client := NewClientTester(Div())
+	defer client.Close()
+
+	u, err := url.Parse("/load-canceled")
+	require.NoError(t, err)
+
+	loadURL(client, u)
+	require.Equal(t, u, lastURLVisited)
+
+	require.NotPanics(t, func() {
+		navigateTo(client, &url.URL{Path: "/load-canceled"}, false)
+	})
+}
@@ -33,17 +33,18 @@ func (a attributes) Set(name string, value any) {
 	}
 }
 
-func (a attributes) Mount(jsElement Value, resolveURL attributeURLResolver) {
+func (a attributes) Mount(jsElement Value, resolveURL attributeURLResolver, basePath string) {
 	for name, value := range a {
 		setJSAttribute(jsElement, name, resolveAttributeURLValue(
 			name,
 			value,
 			resolveURL,
+			basePath,
 		))
 	}
 }
 
-func (a attributes) Update(jsElement Value, b attributes, resolveURL attributeURLResolver) {
+func (a attributes) Update(jsElement Value, b attributes, resolveURL attributeURLResolver, basePath string) {
 	for name := range a {
 		if _, ok := b[name]; !ok {
 			deleteJSAttribute(jsElement, name)
@@ -61,6 +62,7 @@ func (a attributes) Update(jsElement Value, b attributes, resolveURL attributeUR
 			name,
 			value,
 			resolveURL,
+			basePath,
 		))
 	}
 }
@@ -71,14 +73,21 @@ func toAttributeValue(v any) string {
 	return strings.TrimSpace(toString(v))
 }
 
-func resolveAttributeURLValue(name, value string, resolve attributeURLResolver) string {
+func resolveAttributeURLValue(name, value string, resolve attributeURLResolver, basePath string) string {
 	switch name {
 	case "cite",
 		"data",
-		"href",
 		"src":
 		return resolve(value)
 
+	case "href":
+		// Static resources are resolved with the base path while the other
+		// absolute paths are app paths that are prefixed with it.
+		if resolved := resolve(value); resolved != value {
+			return resolved
+		}
+		return hrefWithBasePath(basePath, value)
+
 	case "srcset":
 		srcs := strings.Split(value, ", ")
 		for i, src := range srcs {
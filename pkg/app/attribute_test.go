@@ -56,7 +56,7 @@ func TestAttributesMount(t *testing.T) {
 
 	attributes.Mount(div.JSValue(), func(s string) string {
 		return s
-	})
+	}, "")
 }
 
 func TestAttributesUpdate(t *testing.T) {
@@ -71,7 +71,7 @@ func TestAttributesUpdate(t *testing.T) {
 	t.Run("attribute is deleted", func(t *testing.T) {
 		attributes := make(attributes)
 		attributes.Set("value", "foo")
-		attributes.Update(div.JSValue(), nil, resolveURL)
+		attributes.Update(div.JSValue(), nil, resolveURL, "")
 		require.Empty(t, attributes)
 	})
 
@@ -82,7 +82,7 @@ func TestAttributesUpdate(t *testing.T) {
 		b := make(attributes)
 		b.Set("value", "foo")
 
-		a.Update(div.JSValue(), b, resolveURL)
+		a.Update(div.JSValue(), b, resolveURL, "")
 	})
 
 	t.Run("attribute is updated", func(t *testing.T) {
@@ -92,7 +92,7 @@ func TestAttributesUpdate(t *testing.T) {
 		b := make(attributes)
 		b.Set("value", "bar")
 
-		a.Update(div.JSValue(), b, resolveURL)
+		a.Update(div.JSValue(), b, resolveURL, "")
 		require.Equal(t, "bar", a["value"])
 	})
 }
@@ -167,11 +167,26 @@ func TestResolveAttributeURLValue(t *testing.T) {
 				u.value,
 				func(s string) string {
 					return "/foo/" + s
-				}))
+				},
+				""))
 		})
 	}
 }
 
+func TestResolveAttributeHrefWithBasePath(t *testing.T) {
+	resolveURL := func(s string) string {
+		if isStaticResourcePath(s) {
+			return "/admin" + s
+		}
+		return s
+	}
+
+	require.Equal(t, "/admin/users", resolveAttributeURLValue("href", "/users", resolveURL, "/admin"))
+	require.Equal(t, "/admin/admin/users", resolveAttributeURLValue("href", "/admin/users", resolveURL, "/admin"))
+	require.Equal(t, "/admin/web/app.css", resolveAttributeURLValue("href", "/web/app.css", resolveURL, "/admin"))
+	require.Equal(t, "https://go-app.dev", resolveAttributeURLValue("href", "https://go-app.dev", resolveURL, "/admin"))
+}
+
 func TestSetDeleteJSAttribute(t *testing.T) {
 	utests := []struct {
 		name  string
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"net/url"
+	"strings"
+)
+
+// normalizeBasePath returns the given base path with a leading slash and
+// without trailing slash. "/" and "" both result in an empty base path.
+func normalizeBasePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+// hasBasePath reports whether the given path is located under the given base
+// path.
+func hasBasePath(base, path string) bool {
+	return base == "" ||
+		path == base ||
+		strings.HasPrefix(path, base+"/")
+}
+
+// trimBasePath returns the given path without the given base path. The root
+// path is returned when the path is the base path.
+func trimBasePath(base, path string) string {
+	if !hasBasePath(base, path) {
+		return path
+	}
+
+	path = strings.TrimPrefix(path, base)
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// withBasePath returns the given app URL with its path prefixed by the given
+// base path.
+//
+// App URLs are the ones that only have an absolute path, such as the ones
+// given to Context.Navigate() or returned by URLFor(). URLs with a scheme or a
+// host are browser URLs that already contain the base path, and URLs with a
+// relative path are resolved against the current page. Both are returned as
+// they are.
+func withBasePath(base string, u *url.URL) *url.URL {
+	if u == nil ||
+		base == "" ||
+		u.Scheme != "" ||
+		u.Host != "" ||
+		!strings.HasPrefix(u.Path, "/") {
+		return u
+	}
+
+	prefixed := *u
+	prefixed.Path = base + u.Path
+	if u.RawPath != "" {
+		prefixed.RawPath = base + u.RawPath
+	}
+	return &prefixed
+}
+
+// hrefWithBasePath returns the given href prefixed by the given base path when
+// it is an absolute app path.
+func hrefWithBasePath(base, href string) string {
+	if base == "" ||
+		!strings.HasPrefix(href, "/") ||
+		strings.HasPrefix(href, "//") {
+		return href
+	}
+	return base + href
+}
+
+// routePath returns the path that is matched against the routes for the given
+// page URL path: the path without the root prefix in the web browser, and
+// without the given base path on the server.
+func routePath(base, path string) string {
+	if rootPrefix != "" {
+		path = strings.TrimPrefix(path, rootPrefix)
+	} else {
+		path = trimBasePath(base, path)
+	}
+
+	if path == "" {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBasePath(t *testing.T) {
+	require.Equal(t, "", normalizeBasePath(""))
+	require.Equal(t, "", normalizeBasePath("/"))
+	require.Equal(t, "/admin", normalizeBasePath("admin"))
+	require.Equal(t, "/admin", normalizeBasePath("/admin/"))
+	require.Equal(t, "/apps/admin", normalizeBasePath("/apps/admin"))
+}
+
+func TestTrimBasePath(t *testing.T) {
+	require.Equal(t, "/users", trimBasePath("", "/users"))
+	require.Equal(t, "/", trimBasePath("/admin", "/admin"))
+	require.Equal(t, "/", trimBasePath("/admin", "/admin/"))
+	require.Equal(t, "/users", trimBasePath("/admin", "/admin/users"))
+	require.Equal(t, "/administrators", trimBasePath("/admin", "/administrators"))
+}
+
+func TestWithBasePath(t *testing.T) {
+	utests := []struct {
+		scenario string
+		base     string
+		url      string
+		expected string
+	}{
+		{
+			scenario: "no base path",
+			url:      "/users",
+			expected: "/users",
+		},
+		{
+			scenario: "path",
+			base:     "/admin",
+			url:      "/users?id=42#profile",
+			expected: "/admin/users?id=42#profile",
+		},
+		{
+			scenario: "path that starts with the base path",
+			base:     "/admin",
+			url:      "/admin/users",
+			expected: "/admin/admin/users",
+		},
+		{
+			scenario: "browser url",
+			base:     "/admin",
+			url:      "https://goapp.dev/admin/users",
+			expected: "https://goapp.dev/admin/users",
+		},
+		{
+			scenario: "relative path",
+			base:     "/admin",
+			url:      "users",
+			expected: "users",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			v, err := url.Parse(u.url)
+			require.NoError(t, err)
+			require.Equal(t, u.expected, withBasePath(u.base, v).String())
+		})
+	}
+}
+
+func TestHrefWithBasePath(t *testing.T) {
+	require.Equal(t, "/users", hrefWithBasePath("", "/users"))
+	require.Equal(t, "/admin/users", hrefWithBasePath("/admin", "/users"))
+	require.Equal(t, "/admin/admin/users", hrefWithBasePath("/admin", "/admin/users"))
+	require.Equal(t, "/admin/", hrefWithBasePath("/admin", "/"))
+	require.Equal(t, "users", hrefWithBasePath("/admin", "users"))
+	require.Equal(t, "#top", hrefWithBasePath("/admin", "#top"))
+	require.Equal(t, "//cdn.go-app.dev/x.js", hrefWithBasePath("/admin", "//cdn.go-app.dev/x.js"))
+	require.Equal(t, "https://go-app.dev", hrefWithBasePath("/admin", "https://go-app.dev"))
+}
+
+func TestRoutePath(t *testing.T) {
+	defer func() {
+		rootPrefix = ""
+	}()
+
+	require.Equal(t, "/users/42", routePath("", "/users/42"))
+	require.Equal(t, "/users/42", routePath("/admin", "/admin/users/42"))
+	require.Equal(t, "/", routePath("/admin", "/admin"))
+	require.Equal(t, "/users/42", routePath("/admin", "/users/42"))
+	require.Equal(t, "/admin/users", routePath("/admin", "/admin/admin/users"))
+
+	rootPrefix = "/admin/app"
+	require.Equal(t, "/users/42", routePath("/admin", "/admin/app/users/42"))
+	require.Equal(t, "/", routePath("/admin", "/admin/app"))
+}
@@ -16,6 +16,13 @@ type cacheItem struct {
 
 	// The response body.
 	Body []byte
+
+	// The response entity tag, which is a hash of the body.
+	ETag string
+
+	// The gzip compressed response body. It is empty when the body is already
+	// encoded or when compressing it is not worth it.
+	GzipBody []byte
 }
 
 func (i cacheItem) Len() int {
@@ -33,7 +40,18 @@ func newMemoryCache(size int) *memoryCache {
 	}
 }
 
+// Set stores the given item. Its entity tag is computed and its body is
+// compressed once when its content type benefits from it.
 func (c *memoryCache) Set(i cacheItem) {
+	if i.ETag == "" {
+		i.ETag = `"` + contentHash(i.Body) + `"`
+	}
+	if i.ContentEncoding == "" && i.GzipBody == nil && isCompressibleContentType(i.ContentType) {
+		if b, err := gzipBytes(i.Body); err == nil && len(b) < len(i.Body) {
+			i.GzipBody = b
+		}
+	}
+
 	c.mu.Lock()
 	c.items[i.Path] = i
 	c.mu.Unlock()
@@ -14,6 +14,7 @@ func TestMemoryCache(t *testing.T) {
 		ContentType:     "text/html",
 		ContentEncoding: "gzip",
 		Body:            []byte("test"),
+		ETag:            `"test"`,
 	}
 
 	ic, ok := c.Get(i.Path)
@@ -25,3 +26,52 @@ func TestMemoryCache(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, i, ic)
 }
+
+func TestMemoryCacheETag(t *testing.T) {
+	c := newMemoryCache(5)
+
+	c.Set(cacheItem{
+		Path:        "/a",
+		ContentType: "text/plain",
+		Body:        []byte("a"),
+	})
+	a, _ := c.Get("/a")
+	require.Equal(t, `"`+contentHash([]byte("a"))+`"`, a.ETag)
+
+	c.Set(cacheItem{
+		Path:        "/b",
+		ContentType: "text/plain",
+		Body:        []byte("b"),
+	})
+	b, _ := c.Get("/b")
+	require.NotEqual(t, a.ETag, b.ETag)
+}
+
+func TestMemoryCacheCompression(t *testing.T) {
+	c := newMemoryCache(5)
+
+	c.Set(cacheItem{
+		Path:        "/compressible",
+		ContentType: "application/javascript",
+		Body:        []byte(appJS),
+	})
+	i, _ := c.Get("/compressible")
+	require.NotEmpty(t, i.GzipBody)
+	require.Equal(t, []byte(appJS), testGunzip(t, i.GzipBody))
+
+	c.Set(cacheItem{
+		Path:        "/image",
+		ContentType: "image/png",
+		Body:        []byte(appJS),
+	})
+	i, _ = c.Get("/image")
+	require.Empty(t, i.GzipBody)
+
+	c.Set(cacheItem{
+		Path:        "/tiny",
+		ContentType: "text/plain",
+		Body:        []byte("a"),
+	})
+	i, _ = c.Get("/tiny")
+	require.Empty(t, i.GzipBody)
+}
@@ -2,9 +2,12 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"reflect"
+	"runtime/debug"
 	"strings"
+	"sync"
 
 	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
@@ -28,6 +31,7 @@ type Composer interface {
 	UI
 
 	// Render returns the node tree that define how the component is desplayed.
+	// Several root elements can be returned by using Frag.
 	Render() UI
 
 	// Update update the component appearance. It should be called when a field
@@ -48,6 +52,10 @@ type Composer interface {
 	ValueTo(any) EventHandler
 
 	updateRoot() error
+	showError(error) error
+	setStreamID(int)
+	getRouted() Composer
+	setRouted(Composer)
 	dispatch(func(Context))
 }
 
@@ -61,6 +69,9 @@ type PreRenderer interface {
 	// If pre-rendering requires blocking operations such as performing an HTTP
 	// request, ensure that they are done synchronously. A good practice is to
 	// avoid using goroutines during pre-rendering.
+	//
+	// The incoming HTTP request is returned by ctx.Page().Request(), and the
+	// request-scoped values are also available with ctx.Value().
 	OnPreRender(Context)
 }
 
@@ -103,6 +114,29 @@ type Navigator interface {
 	OnNav(Context)
 }
 
+// NavLeaver is the interface that describes a component that can perform
+// additional actions before being navigated away from, such as running exit
+// transitions.
+type NavLeaver interface {
+	Composer
+
+	// The function called before the component is replaced by a navigation.
+	// The navigation continues once the given leave function is called. It is
+	// always called on the UI goroutine.
+	OnBeforeLeave(ctx Context, leave func())
+}
+
+// NavEnterer is the interface that describes a component that can perform
+// additional actions after being displayed by a navigation, such as running
+// enter transitions.
+type NavEnterer interface {
+	Composer
+
+	// The function called after the component is displayed by a navigation. It
+	// is always called on the UI goroutine.
+	OnAfterEnter(Context)
+}
+
 // Updater is the interface that describes a component that can do additional
 // instructions when one of its exported fields is modified by its nearest
 // parent component.
@@ -112,6 +146,41 @@ type Updater interface {
 	OnUpdate(Context)
 }
 
+// ErrorBoundary is the interface that describes a component that catches the
+// errors and panics that occur within its descendants, and displays a fallback
+// content instead of crashing the app.
+type ErrorBoundary interface {
+	Composer
+
+	// The function called when a descendant fails or panics while being
+	// mounted or updated, or while executing a dispatched function such as
+	// OnMount or an event handler. It returns the elements displayed in place
+	// of the component content.
+	//
+	// The fallback content is displayed until the component is updated by its
+	// nearest parent component with modified fields, which triggers a new
+	// attempt to render its content. Meanwhile, OnError is called again each
+	// time the component is updated. It is always called on the UI goroutine.
+	OnError(ctx Context, err error) UI
+}
+
+// UpdateChecker is the interface that describes a component that decides
+// whether it is updated when its nearest parent component is rendered.
+//
+// Implementing it skips the comparison of exported fields that is performed by
+// default, which can be expensive for components with large slices or maps.
+type UpdateChecker interface {
+	// The function called when the nearest parent component is rendered, with
+	// the newly rendered version of the component. It reports whether the
+	// component should be updated.
+	//
+	// When it returns false, the next version is discarded. When it returns
+	// true, all the exported fields are copied from the next version without
+	// being compared and the component is rendered again. It is always called
+	// on the UI goroutine.
+	ShouldUpdate(next Composer) bool
+}
+
 // AppUpdater is the interface that describes a component that is notified when
 // the application is updated.
 type AppUpdater interface {
@@ -139,12 +208,37 @@ type Resizer interface {
 	OnResize(Context)
 }
 
+// ShareTargetHandler is the interface that describes a component that is
+// notified when data is shared with the app through the Handler.ShareTarget.
+type ShareTargetHandler interface {
+	// The function called when data is shared with the app. It is always
+	// called on the UI goroutine.
+	OnShareTarget(Context, ShareTargetData)
+}
+
+// FileLaunchHandler is the interface that describes a component that is
+// notified when the app is launched with files through the
+// Handler.FileHandlers.
+type FileLaunchHandler interface {
+	// The function called when the app is launched with files. It is always
+	// called on the UI goroutine.
+	OnFileLaunch(Context, []SharedFile)
+}
+
 // Component events.
 type nav struct{}
 type appUpdate struct{}
 type appInstallChange struct{}
 type resize struct{}
 
+type shareTarget struct {
+	data ShareTargetData
+}
+
+type fileLaunch struct {
+	files []SharedFile
+}
+
 // Compo represents the base struct to use in order to build a component.
 type Compo struct {
 	disp       Dispatcher
@@ -153,6 +247,10 @@ type Compo struct {
 	parentElem UI
 	root       UI
 	this       Composer
+	key        any
+	err        error
+	streamID   int
+	routed     Composer
 }
 
 // Kind returns the ui element kind.
@@ -162,6 +260,9 @@ func (c *Compo) Kind() Kind {
 
 // JSValue returns the javascript value of the component root.
 func (c *Compo) JSValue() Value {
+	if c.root == nil {
+		return nil
+	}
 	return c.root.JSValue()
 }
 
@@ -261,6 +362,15 @@ func (c *Compo) getEventHandlers() eventHandlers {
 	return nil
 }
 
+func (c *Compo) getKey() any {
+	return c.key
+}
+
+func (c *Compo) setKey(v any) {
+	checkKey(v)
+	c.key = v
+}
+
 func (c *Compo) getParent() UI {
 	return c.parentElem
 }
@@ -274,6 +384,18 @@ func (c *Compo) getChildren() []UI {
 }
 
 func (c *Compo) mount(d Dispatcher) error {
+	return c.mountWith(d, func(root UI) error {
+		return mount(d, root)
+	})
+}
+
+func (c *Compo) hydrate(d Dispatcher, cursor *hydrationCursor) error {
+	return c.mountWith(d, func(root UI) error {
+		return hydrate(d, root, cursor)
+	})
+}
+
+func (c *Compo) mountWith(d Dispatcher, mountRoot func(UI) error) error {
 	if c.Mounted() {
 		return errors.New("mounting component failed").
 			WithTag("reason", "already mounted").
@@ -286,10 +408,27 @@ func (c *Compo) mount(d Dispatcher) error {
 	}
 
 	c.disp = d
-	c.ctx, c.ctxCancel = context.WithCancel(context.Background())
-
-	root := c.render()
-	if err := mount(d, root); err != nil {
+	c.ctx, c.ctxCancel = context.WithCancel(d.getBaseContext())
+
+	// The root is set before being mounted in order to let the nearest error
+	// boundary dismount what has been mounted when a panic occurs.
+	var root UI
+	err := c.catch(func() error {
+		root = c.render()
+		c.root = root
+		return mountRoot(root)
+	})
+	if boundary, ok := c.self().(ErrorBoundary); ok && err != nil {
+		if root != nil {
+			dismount(root)
+		}
+		c.logError(err)
+		c.err = err
+		root = c.renderError(boundary)
+		c.root = nil
+		err = mount(d, root)
+	}
+	if err != nil {
 		return errors.New("mounting component failed").
 			WithTag("name", c.name()).
 			WithTag("kind", c.Kind()).
@@ -313,8 +452,14 @@ func (c *Compo) mount(d Dispatcher) error {
 }
 
 func (c *Compo) dismount() {
+	if c.ctxCancel == nil {
+		return
+	}
+
 	c.ctxCancel()
-	dismount(c.root)
+	if c.root != nil {
+		dismount(c.root)
+	}
 
 	if dismounter, ok := c.this.(Dismounter); ok {
 		dismounter.OnDismount()
@@ -324,7 +469,8 @@ func (c *Compo) dismount() {
 func (c *Compo) canUpdateWith(v UI) bool {
 	return c.Mounted() &&
 		c.Kind() == v.Kind() &&
-		c.name() == v.name()
+		c.name() == v.name() &&
+		c.getKey() == v.getKey()
 }
 
 func (c *Compo) updateWith(v UI) error {
@@ -338,32 +484,37 @@ func (c *Compo) updateWith(v UI) error {
 			WithTag("new", reflect.TypeOf(v))
 	}
 
+	checker, hasChecker := c.self().(UpdateChecker)
+	if hasChecker && !checker.ShouldUpdate(v.(Composer)) {
+		return nil
+	}
+
 	aval := reflect.Indirect(reflect.ValueOf(c.self()))
 	bval := reflect.Indirect(reflect.ValueOf(v))
-	compotype := reflect.ValueOf(c).Elem().Type()
 	haveModifiedFields := false
 
-	for i := 0; i < aval.NumField(); i++ {
-		a := aval.Field(i)
-		b := bval.Field(i)
+	for _, f := range getCompoFields(aval.Type()) {
+		a := aval.Field(f.index)
+		b := bval.Field(f.index)
+
+		switch {
+		case hasChecker:
 
-		if a.Type() == compotype {
+		case f.identity && identical(a, b):
 			continue
-		}
 
-		if !a.CanSet() {
+		case !f.identity && reflect.DeepEqual(a.Interface(), b.Interface()):
 			continue
 		}
 
-		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
-			a.Set(b)
-			haveModifiedFields = true
-		}
+		a.Set(b)
+		haveModifiedFields = true
 	}
 
 	if !haveModifiedFields {
 		return nil
 	}
+	c.err = nil
 
 	if err := c.updateRoot(); err != nil {
 		return errors.New("updating root failed").Wrap(err)
@@ -377,6 +528,69 @@ func (c *Compo) updateWith(v UI) error {
 	return nil
 }
 
+// compoField describes an exported field of a component that is compared and
+// copied when the component is updated by its nearest parent component.
+type compoField struct {
+	index    int
+	identity bool
+}
+
+var compoFields sync.Map
+
+// getCompoFields returns the updatable fields of the given component struct
+// type.
+//
+// Fields tagged with `app:"identity"` are compared by identity rather than by
+// deep equality: slices are equal when they share the same underlying array
+// and length, and maps, pointers and channels are equal when they point to the
+// same value.
+func getCompoFields(t reflect.Type) []compoField {
+	if fields, ok := compoFields.Load(t); ok {
+		return fields.([]compoField)
+	}
+
+	compoType := reflect.TypeOf(Compo{})
+	fields := make([]compoField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type == compoType || !f.IsExported() {
+			continue
+		}
+
+		fields = append(fields, compoField{
+			index:    i,
+			identity: f.Tag.Get("app") == "identity",
+		})
+	}
+
+	compoFields.Store(t, fields)
+	return fields
+}
+
+func identical(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice:
+		return a.Pointer() == b.Pointer() &&
+			a.Len() == b.Len() &&
+			a.IsNil() == b.IsNil()
+
+	case reflect.Map, reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		a = a.Elem()
+		b = b.Elem()
+		return a.Type() == b.Type() && identical(a, b)
+
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
 func (c *Compo) dispatch(fn func(Context)) {
 	c.getDispatcher().Dispatch(Dispatch{
 		Mode:     Update,
@@ -386,13 +600,25 @@ func (c *Compo) dispatch(fn func(Context)) {
 }
 
 func (c *Compo) updateRoot() error {
-	a := c.root
-	b := c.render()
+	boundary, isBoundary := c.self().(ErrorBoundary)
+	if isBoundary && c.err != nil {
+		return c.setRoot(c.renderError(boundary))
+	}
 
-	if canUpdate(a, b) {
-		return update(a, b)
+	err := c.catch(func() error {
+		return c.setRoot(c.render())
+	})
+	if isBoundary && err != nil {
+		return c.showError(err)
+	}
+	return err
+}
+
+func (c *Compo) setRoot(v UI) error {
+	if canUpdate(c.root, v) {
+		return update(c.root, v)
 	}
-	return c.replaceRoot(b)
+	return c.replaceRoot(v)
 }
 
 func (c *Compo) replaceRoot(v UI) error {
@@ -410,11 +636,8 @@ func (c *Compo) replaceRoot(v UI) error {
 			Wrap(err)
 	}
 
-	var parent UI
-	for parent = c.getParent(); parent != nil && parent.Kind() != HTML; parent = parent.getParent() {
-	}
-
-	if parent == nil {
+	parent, ok := jsParent(c.self())
+	if !ok {
 		return errors.New("replacing component root failed").
 			WithTag("kind", c.Kind()).
 			WithTag("name", c.name()).
@@ -423,18 +646,114 @@ func (c *Compo) replaceRoot(v UI) error {
 
 	new.setParent(c.self())
 	c.root = new
-
-	oldjs := old.JSValue()
-	newjs := v.JSValue()
-	parent.JSValue().replaceChild(newjs, oldjs)
+	replaceJSNodes(parent, new, old)
 
 	dismount(old)
 	return nil
 }
 
 func (c *Compo) render() UI {
-	elems := FilterUIElems(c.this.Render())
-	return elems[0]
+	root := makeRoot(c.this.Render())
+	bindOutlets(c.this, root)
+	return root
+}
+
+func (c *Compo) showError(err error) error {
+	boundary, ok := c.self().(ErrorBoundary)
+	if !ok {
+		return errors.New("component is not an error boundary").
+			WithTag("name", c.name())
+	}
+
+	c.logError(err)
+	c.err = err
+	return c.setRoot(c.renderError(boundary))
+}
+
+func (c *Compo) renderError(b ErrorBoundary) UI {
+	return makeRoot(b.OnError(makeContext(c.self()), c.err))
+}
+
+func (c *Compo) logError(err error) {
+	Log(errors.New("component error caught by error boundary").
+		WithTag("name", c.name()).
+		WithTag("kind", c.Kind()).
+		Wrap(err),
+	)
+}
+
+// catch executes the given function and converts the panic that occurs within
+// it into an error when the component is an error boundary. Other components
+// let panics propagate to their nearest error boundary.
+func (c *Compo) catch(fn func() error) (err error) {
+	if _, ok := c.self().(ErrorBoundary); !ok {
+		return fn()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError(r)
+		}
+	}()
+	return fn()
+}
+
+func makeRoot(v UI) UI {
+	elems := FilterUIElems(v)
+	if len(elems) == 1 {
+		return elems[0]
+	}
+	return Frag(elems...)
+}
+
+// catchError displays the fallback of the nearest mounted error boundary
+// ancestor of the given element. It reports whether the error has been caught.
+func catchError(n UI, err error) bool {
+	for p := n.getParent(); p != nil; p = p.getParent() {
+		boundary, ok := p.(ErrorBoundary)
+		if !ok || !boundary.Mounted() {
+			continue
+		}
+
+		if err := boundary.showError(err); err != nil {
+			Log(errors.New("displaying error boundary fallback failed").
+				WithTag("name", boundary.name()).
+				Wrap(err),
+			)
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// hasErrorBoundary reports whether one of the ancestors of the given element is
+// a mounted error boundary that can catch its errors with catchError.
+func hasErrorBoundary(n UI) bool {
+	for p := n.getParent(); p != nil; p = p.getParent() {
+		if boundary, ok := p.(ErrorBoundary); ok && boundary.Mounted() {
+			return true
+		}
+	}
+	return false
+}
+
+// repanic panics with the given recovered value and the stack trace of the
+// panic that it has been recovered from.
+func repanic(r any) {
+	err := errors.New("panic").WithTag("stack", string(debug.Stack()))
+	if e, ok := r.(error); ok {
+		panic(err.Wrap(e))
+	}
+	panic(err.WithTag("value", fmt.Sprint(r)))
+}
+
+func panicError(r any) error {
+	err := errors.New("panic").WithTag("value", fmt.Sprint(r))
+	if e, ok := r.(error); ok {
+		return err.Wrap(e)
+	}
+	return err
 }
 
 func (c *Compo) onComponentEvent(le any) {
@@ -450,6 +769,12 @@ func (c *Compo) onComponentEvent(le any) {
 
 	case resize:
 		c.onResize(le)
+
+	case shareTarget:
+		c.onShareTarget(le)
+
+	case fileLaunch:
+		c.onFileLaunch(le)
 	}
 
 	c.root.onComponentEvent(le)
@@ -481,12 +806,48 @@ func (c *Compo) onResize(r resize) {
 	}
 }
 
+func (c *Compo) onShareTarget(st shareTarget) {
+	if handler, ok := c.self().(ShareTargetHandler); ok {
+		c.dispatch(func(ctx Context) {
+			handler.OnShareTarget(ctx, st.data)
+		})
+	}
+}
+
+func (c *Compo) onFileLaunch(fl fileLaunch) {
+	if handler, ok := c.self().(FileLaunchHandler); ok {
+		c.dispatch(func(ctx Context) {
+			handler.OnFileLaunch(ctx, fl.files)
+		})
+	}
+}
+
 func (c *Compo) html(w io.Writer) {
 	if c.root == nil {
 		c.root = c.render()
 		c.root.setSelf(c.root)
 	}
+
+	if c.streamID == 0 {
+		c.root.html(w)
+		return
+	}
+
+	fmt.Fprintf(w, "<!--%s%d-->", streamMarker, c.streamID)
 	c.root.html(w)
+	fmt.Fprintf(w, "<!--/%s%d-->", streamMarker, c.streamID)
+}
+
+func (c *Compo) setStreamID(v int) {
+	c.streamID = v
+}
+
+func (c *Compo) getRouted() Composer {
+	return c.routed
+}
+
+func (c *Compo) setRouted(v Composer) {
+	c.routed = v
 }
 
 func (c *Compo) htmlWithIndent(w io.Writer, indent int) {
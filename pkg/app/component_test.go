@@ -2,8 +2,10 @@ package app
 
 import (
 	"net/url"
+	"reflect"
 	"testing"
 
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -537,3 +539,302 @@ func (b *bar) OnUpdate(ctx Context) {
 func (b *bar) Render() UI {
 	return Text(b.Value)
 }
+
+func TestUpdateChecker(t *testing.T) {
+	c := &updateCheckerCompo{Value: "a"}
+	d := NewClientTester(c)
+	defer d.Close()
+
+	d.Mount(&updateCheckerCompo{Value: "b", Skip: true})
+	d.Consume()
+	require.Equal(t, "a", c.Value)
+
+	d.Mount(&updateCheckerCompo{Value: "b"})
+	d.Consume()
+	require.Equal(t, "b", c.Value)
+	require.NoError(t, TestMatch(c, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Text("b"),
+	}))
+}
+
+func TestIdentityFields(t *testing.T) {
+	items := []string{"a", "b"}
+	c := &identityCompo{Items: items}
+	d := NewClientTester(c)
+	defer d.Close()
+
+	d.Mount(&identityCompo{Items: items})
+	d.Consume()
+	require.Equal(t, 0, c.updates)
+
+	d.Mount(&identityCompo{Items: []string{"a", "b"}})
+	d.Consume()
+	require.Equal(t, 1, c.updates)
+
+	d.Mount(&identityCompo{Items: c.Items[:1]})
+	d.Consume()
+	require.Equal(t, 2, c.updates)
+}
+
+func TestIdentical(t *testing.T) {
+	s := []int{1, 2, 3}
+	m := map[string]int{"a": 1}
+	i := 42
+	var nilSlice []int
+
+	utests := []struct {
+		scenario string
+		a        any
+		b        any
+		expected bool
+	}{
+		{
+			scenario: "same slice",
+			a:        s,
+			b:        s,
+			expected: true,
+		},
+		{
+			scenario: "slice with different length",
+			a:        s,
+			b:        s[:2],
+			expected: false,
+		},
+		{
+			scenario: "equal slices",
+			a:        s,
+			b:        []int{1, 2, 3},
+			expected: false,
+		},
+		{
+			scenario: "nil and empty slices",
+			a:        nilSlice,
+			b:        []int{},
+			expected: false,
+		},
+		{
+			scenario: "same map",
+			a:        m,
+			b:        m,
+			expected: true,
+		},
+		{
+			scenario: "equal maps",
+			a:        m,
+			b:        map[string]int{"a": 1},
+			expected: false,
+		},
+		{
+			scenario: "same pointer",
+			a:        &i,
+			b:        &i,
+			expected: true,
+		},
+		{
+			scenario: "equal strings",
+			a:        "hello",
+			b:        "hello",
+			expected: true,
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			a := reflect.ValueOf(u.a)
+			b := reflect.ValueOf(u.b)
+			require.Equal(t, u.expected, identical(a, b))
+		})
+	}
+}
+
+type updateCheckerCompo struct {
+	Compo
+
+	Value string
+	Skip  bool
+}
+
+func (c *updateCheckerCompo) ShouldUpdate(next Composer) bool {
+	return !next.(*updateCheckerCompo).Skip
+}
+
+func (c *updateCheckerCompo) Render() UI {
+	return Text(c.Value)
+}
+
+type identityCompo struct {
+	Compo
+
+	Items   []string `app:"identity"`
+	updates int
+}
+
+func (c *identityCompo) OnUpdate(ctx Context) {
+	c.updates++
+}
+
+func (c *identityCompo) Render() UI {
+	return Ul().Body(
+		Range(c.Items).Slice(func(i int) UI {
+			return Li().Text(c.Items[i])
+		}),
+	)
+}
+
+func TestErrorBoundary(t *testing.T) {
+	t.Run("render panic on mount", func(t *testing.T) {
+		b := &errorBoundary{Content: &panicCompo{PanicOnRender: true}}
+		d := NewClientTester(b)
+		defer d.Close()
+
+		require.Error(t, b.err)
+		require.NoError(t, TestMatch(b, TestUIDescriptor{
+			Path:     TestPath(0),
+			Expected: P(),
+		}))
+	})
+
+	t.Run("render panic on update", func(t *testing.T) {
+		b := &errorBoundary{Content: &panicCompo{}}
+		d := NewClientTester(b)
+		defer d.Close()
+		require.NoError(t, b.err)
+
+		d.Mount(&errorBoundary{Content: &panicCompo{PanicOnRender: true}})
+		d.Consume()
+		require.Error(t, b.err)
+		require.NoError(t, TestMatch(b, TestUIDescriptor{
+			Path:     TestPath(0),
+			Expected: P(),
+		}))
+	})
+
+	t.Run("on mount panic", func(t *testing.T) {
+		b := &errorBoundary{Content: &panicCompo{PanicOnMount: true}}
+		d := NewClientTester(b)
+		defer d.Close()
+
+		require.Error(t, b.err)
+		require.NoError(t, TestMatch(b, TestUIDescriptor{
+			Path:     TestPath(0),
+			Expected: P(),
+		}))
+	})
+
+	t.Run("dispatch panic", func(t *testing.T) {
+		c := &panicCompo{}
+		b := &errorBoundary{Content: c}
+		d := NewClientTester(b)
+		defer d.Close()
+
+		d.Dispatch(Dispatch{
+			Mode:   Update,
+			Source: c,
+			Function: func(Context) {
+				panic("handler failed")
+			},
+		})
+		d.Consume()
+		require.Error(t, b.err)
+	})
+
+	t.Run("panic without boundary", func(t *testing.T) {
+		c := &panicCompo{}
+		d := NewClientTester(c)
+		defer d.Close()
+
+		d.Dispatch(Dispatch{
+			Mode:   Update,
+			Source: c,
+			Function: func(Context) {
+				panic("handler failed")
+			},
+		})
+		require.PanicsWithValue(t, "handler failed", d.Consume)
+	})
+
+	t.Run("mounted elements are dismounted on mount panic", func(t *testing.T) {
+		sibling := &dismountCounterCompo{}
+		div := Div().Body(
+			Span(),
+			sibling,
+			&panicCompo{PanicOnRender: true},
+		)
+		b := &errorBoundary{Content: div}
+		d := NewClientTester(b)
+		defer d.Close()
+
+		require.Error(t, b.err)
+		require.False(t, div.Mounted())
+		require.False(t, div.getChildren()[0].Mounted())
+		require.False(t, sibling.Mounted())
+		require.Equal(t, 1, sibling.dismounts)
+		require.NoError(t, TestMatch(b, TestUIDescriptor{
+			Path:     TestPath(0),
+			Expected: P(),
+		}))
+	})
+}
+
+type dismountCounterCompo struct {
+	Compo
+
+	dismounts int
+}
+
+func (c *dismountCounterCompo) OnDismount() {
+	c.dismounts++
+}
+
+type errorBoundary struct {
+	Compo
+
+	Content UI
+	err     error
+}
+
+func (b *errorBoundary) OnError(ctx Context, err error) UI {
+	b.err = err
+	return P().Text("oops")
+}
+
+func (b *errorBoundary) Render() UI {
+	return Div().Body(b.Content)
+}
+
+type panicCompo struct {
+	Compo
+
+	PanicOnRender bool
+	PanicOnMount  bool
+}
+
+func (c *panicCompo) OnMount(ctx Context) {
+	if c.PanicOnMount {
+		panic("mount failed")
+	}
+}
+
+func (c *panicCompo) Render() UI {
+	if c.PanicOnRender {
+		panic("render failed")
+	}
+	return Text("ok")
+}
+
+func TestRepanic(t *testing.T) {
+	defer func() {
+		err, ok := recover().(errors.Error)
+		require.True(t, ok)
+		require.Equal(t, "boom", err.Tag("value"))
+		require.Contains(t, err.Tag("stack"), "TestRepanic")
+	}()
+
+	func() {
+		defer func() {
+			repanic(recover())
+		}()
+		panic("boom")
+	}()
+}
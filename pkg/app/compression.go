@@ -0,0 +1,138 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// The HTTP header that reports the size of a precompressed file once it
+	// is decompressed. It is used to report the app.wasm loading progress.
+	uncompressedContentLengthHeader = "X-Uncompressed-Content-Length"
+)
+
+// precompressedEncodings are the content encodings of the precompressed files
+// that are served in place of the requested ones, by order of preference.
+var precompressedEncodings = []struct {
+	name      string
+	extension string
+}{
+	{name: "br", extension: ".br"},
+	{name: "gzip", extension: ".gz"},
+}
+
+// acceptsEncoding reports whether the given content encoding is accepted by
+// the Accept-Encoding header of the given request.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	accepted := false
+
+	for _, v := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(v, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != encoding && name != "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			k, v, _ := strings.Cut(p, "=")
+			if strings.TrimSpace(k) != "q" {
+				continue
+			}
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+
+		if name == encoding {
+			return q > 0
+		}
+		accepted = q > 0
+	}
+
+	return accepted
+}
+
+// isCompressibleContentType reports whether a response with the given content
+// type benefits from being compressed.
+func isCompressibleContentType(contentType string) bool {
+	contentType, _, _ = strings.Cut(contentType, ";")
+
+	switch {
+	case strings.HasPrefix(contentType, "text/"),
+		strings.HasSuffix(contentType, "json"),
+		strings.HasSuffix(contentType, "xml"),
+		contentType == "application/javascript",
+		contentType == "application/wasm",
+		contentType == "image/svg+xml":
+		return true
+
+	default:
+		return false
+	}
+}
+
+// gzipBytes returns the gzip compressed version of the given bytes.
+func gzipBytes(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// servePrecompressedFile serves the precompressed sibling of the file with the
+// given name and uncompressed size, such as app.wasm.br for app.wasm, when it
+// exists in the given directory and when its encoding is accepted by the
+// request. It reports whether the file has been served.
+func servePrecompressedFile(w http.ResponseWriter, r *http.Request, dir http.FileSystem, name string, size int64) bool {
+	for _, e := range precompressedEncodings {
+		if !acceptsEncoding(r, e.name) {
+			continue
+		}
+
+		f, err := dir.Open(name + e.extension)
+		if err != nil {
+			continue
+		}
+
+		stat, err := f.Stat()
+		if err != nil || stat.IsDir() {
+			f.Close()
+			continue
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := w.Header()
+		header.Set("Content-Encoding", e.name)
+		if etag := header.Get("ETag"); etag != "" {
+			header.Set("ETag", strings.TrimSuffix(etag, `"`)+"-"+e.name+`"`)
+		}
+		header.Set("Content-Type", contentType)
+		header.Add("Vary", "Accept-Encoding")
+		header.Set(uncompressedContentLengthHeader, strconv.FormatInt(size, 10))
+
+		http.ServeContent(w, r, name, stat.ModTime(), f)
+		f.Close()
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsEncoding(t *testing.T) {
+	utests := []struct {
+		scenario       string
+		acceptEncoding string
+		encoding       string
+		expected       bool
+	}{
+		{
+			scenario: "no header",
+			encoding: "gzip",
+		},
+		{
+			scenario:       "listed",
+			acceptEncoding: "gzip, deflate, br",
+			encoding:       "br",
+			expected:       true,
+		},
+		{
+			scenario:       "not listed",
+			acceptEncoding: "gzip, deflate",
+			encoding:       "br",
+		},
+		{
+			scenario:       "with quality",
+			acceptEncoding: "br;q=0.8, gzip;q=1.0",
+			encoding:       "br",
+			expected:       true,
+		},
+		{
+			scenario:       "refused",
+			acceptEncoding: "br;q=0, gzip",
+			encoding:       "br",
+		},
+		{
+			scenario:       "wildcard",
+			acceptEncoding: "*",
+			encoding:       "gzip",
+			expected:       true,
+		},
+		{
+			scenario:       "refused with wildcard",
+			acceptEncoding: "*, gzip;q=0",
+			encoding:       "gzip",
+		},
+		{
+			scenario:       "uppercase",
+			acceptEncoding: "GZIP",
+			encoding:       "gzip",
+			expected:       true,
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Encoding", u.acceptEncoding)
+			require.Equal(t, u.expected, acceptsEncoding(r, u.encoding))
+		})
+	}
+}
+
+func TestIsCompressibleContentType(t *testing.T) {
+	require.True(t, isCompressibleContentType("text/css"))
+	require.True(t, isCompressibleContentType("text/html; charset=utf-8"))
+	require.True(t, isCompressibleContentType("application/javascript"))
+	require.True(t, isCompressibleContentType("application/manifest+json"))
+	require.True(t, isCompressibleContentType("application/wasm"))
+	require.False(t, isCompressibleContentType("image/png"))
+	require.False(t, isCompressibleContentType(""))
+}
+
+func TestGzipBytes(t *testing.T) {
+	b := bytes.Repeat([]byte("go-app "), 100)
+
+	compressed, err := gzipBytes(b)
+	require.NoError(t, err)
+	require.Less(t, len(compressed), len(b))
+	require.Equal(t, b, testGunzip(t, compressed))
+}
+
+func testGunzip(t *testing.T, b []byte) []byte {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	defer r.Close()
+
+	decompressed, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return decompressed
+}
@@ -95,6 +95,10 @@ func (c condition) getEventHandlers() eventHandlers {
 	return nil
 }
 
+func (c condition) getKey() any {
+	return nil
+}
+
 func (c condition) getParent() UI {
 	return nil
 }
@@ -112,6 +116,10 @@ func (c condition) mount(Dispatcher) error {
 		WithTag("kind", c.Kind())
 }
 
+func (c condition) hydrate(d Dispatcher, cursor *hydrationCursor) error {
+	return c.mount(d)
+}
+
 func (c condition) dismount() {
 }
 
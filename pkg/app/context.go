@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,26 @@ type Context interface {
 	// Returns the current page.
 	Page() Page
 
+	// Returns the value of the parameter with the given name, captured from
+	// the current page path by the route that matches it. Returns an empty
+	// string when there is no such parameter.
+	//
+	// Eg: "42" for the parameter "id" with the "/users/{id}" route and the
+	// "/users/42" path.
+	Param(name string) string
+
+	// Returns the value of the parameter with the given name parsed as an
+	// integer.
+	ParamInt(name string) (int, error)
+
+	// Returns the value of the parameter with the given name parsed as a UUID.
+	ParamUUID(name string) (uuid.UUID, error)
+
+	// Stores the data loaded by the loader of the current route into the given
+	// receiver. Returns an error when the route does not have a loader or
+	// when the loader failed.
+	LoaderData(recv any) error
+
 	// Executes the given function on the UI goroutine and notifies the
 	// context's nearest component to update its state.
 	Dispatch(fn func(Context))
@@ -208,6 +229,34 @@ func (ctx uiContext) Page() Page {
 	return ctx.page
 }
 
+func (ctx uiContext) Param(name string) string {
+	return routes.params(routePath(ctx.Dispatcher().getBasePath(), ctx.Page().URL().Path))[name]
+}
+
+func (ctx uiContext) ParamInt(name string) (int, error) {
+	v, err := strconv.Atoi(ctx.Param(name))
+	if err != nil {
+		return 0, errors.New("parsing int parameter failed").
+			WithTag("name", name).
+			Wrap(err)
+	}
+	return v, nil
+}
+
+func (ctx uiContext) ParamUUID(name string) (uuid.UUID, error) {
+	v, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		return uuid.UUID{}, errors.New("parsing uuid parameter failed").
+			WithTag("name", name).
+			Wrap(err)
+	}
+	return v, nil
+}
+
+func (ctx uiContext) LoaderData(recv any) error {
+	return ctx.disp.getLoaderResult().decode(recv)
+}
+
 func (ctx uiContext) Dispatch(fn func(Context)) {
 	ctx.Dispatcher().Dispatch(Dispatch{
 		Mode:     Update,
@@ -252,7 +301,7 @@ func (ctx uiContext) NewActionWithValue(name string, v any, tags ...Tagger) {
 }
 
 func (ctx uiContext) Async(fn func()) {
-	ctx.Dispatcher().Async(fn)
+	ctx.Dispatcher().asyncFrom(ctx.src, fn)
 }
 
 func (ctx uiContext) After(d time.Duration, fn func(Context)) {
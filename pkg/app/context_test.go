@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -163,3 +164,32 @@ func TestContextStates(t *testing.T) {
 	client.Consume()
 	require.Equal(t, "bye", v)
 }
+
+func TestContextParams(t *testing.T) {
+	Route("/test-context-params/{id:int}/{uid:uuid}/{name}", &hello{})
+
+	d := NewClientTester(&hello{})
+	defer d.Close()
+
+	d.Nav(&url.URL{Path: "/test-context-params/42/0b6f7a0e-4d5f-4b45-9b0a-0d0bd3b0b3c1/maxence"})
+	d.Consume()
+	ctx := d.Context()
+
+	require.Equal(t, "42", ctx.Param("id"))
+	require.Equal(t, "maxence", ctx.Param("name"))
+	require.Empty(t, ctx.Param("unknown"))
+
+	id, err := ctx.ParamInt("id")
+	require.NoError(t, err)
+	require.Equal(t, 42, id)
+
+	uid, err := ctx.ParamUUID("uid")
+	require.NoError(t, err)
+	require.Equal(t, "0b6f7a0e-4d5f-4b45-9b0a-0d0bd3b0b3c1", uid.String())
+
+	_, err = ctx.ParamInt("name")
+	require.Error(t, err)
+
+	_, err = ctx.ParamUUID("name")
+	require.Error(t, err)
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// ContentSecurityPolicy describes the Content-Security-Policy header that is
+// sent with the server-side pre-rendered pages.
+//
+// A nonce is generated for each page request. It is set on the script, style
+// and link elements of the page head, including the ones defined in
+// Handler.RawHeaders, and on the scripts that the framework inlines in the page
+// body. It is added to the script-src and style-src directives. The elements
+// rendered by components do not get it: components opt in by setting the
+// value returned by Page.Nonce() on the elements they trust.
+//
+// Since the app can not run without WebAssembly, 'wasm-unsafe-eval' is added to
+// the script-src directive.
+//
+// Browsers ignore 'unsafe-inline' when a nonce is allowed. The nonce is then
+// not added to a style-src directive that contains 'unsafe-inline'. Otherwise,
+// a "style-src-attr 'unsafe-inline'" directive is added, unless one is already
+// defined, in order to keep the style attributes set with Style() and Styles()
+// working on pre-rendered pages.
+//
+// Eg:
+//
+//	app.Handler{
+//		ContentSecurityPolicy: app.CSP().
+//			DefaultSrc("'self'").
+//			ImgSrc("'self'", "data:").
+//			ConnectSrc("'self'", "https://api.example.com"),
+//	}
+type ContentSecurityPolicy struct {
+	directives []cspDirective
+	reportOnly bool
+}
+
+type cspDirective struct {
+	name    string
+	sources []string
+}
+
+// CSP returns an empty Content-Security-Policy.
+func CSP() *ContentSecurityPolicy {
+	return &ContentSecurityPolicy{}
+}
+
+// Directive adds the given sources to the named directive.
+func (p *ContentSecurityPolicy) Directive(name string, sources ...string) *ContentSecurityPolicy {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for i, d := range p.directives {
+		if d.name == name {
+			p.directives[i].sources = append(d.sources, sources...)
+			return p
+		}
+	}
+
+	p.directives = append(p.directives, cspDirective{
+		name:    name,
+		sources: sources,
+	})
+	return p
+}
+
+// DefaultSrc adds the given sources to the default-src directive.
+func (p *ContentSecurityPolicy) DefaultSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("default-src", sources...)
+}
+
+// ScriptSrc adds the given sources to the script-src directive.
+func (p *ContentSecurityPolicy) ScriptSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("script-src", sources...)
+}
+
+// StyleSrc adds the given sources to the style-src directive.
+func (p *ContentSecurityPolicy) StyleSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("style-src", sources...)
+}
+
+// ImgSrc adds the given sources to the img-src directive.
+func (p *ContentSecurityPolicy) ImgSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("img-src", sources...)
+}
+
+// FontSrc adds the given sources to the font-src directive.
+func (p *ContentSecurityPolicy) FontSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("font-src", sources...)
+}
+
+// ConnectSrc adds the given sources to the connect-src directive.
+func (p *ContentSecurityPolicy) ConnectSrc(sources ...string) *ContentSecurityPolicy {
+	return p.Directive("connect-src", sources...)
+}
+
+// ReportURI sets the URI where the policy violations are reported.
+func (p *ContentSecurityPolicy) ReportURI(uri string) *ContentSecurityPolicy {
+	return p.Directive("report-uri", uri)
+}
+
+// ReportOnly reports whether the policy violations are only reported rather
+// than blocked. The Content-Security-Policy-Report-Only header is then sent
+// instead.
+func (p *ContentSecurityPolicy) ReportOnly(v bool) *ContentSecurityPolicy {
+	p.reportOnly = v
+	return p
+}
+
+// header returns the name and the value of the header that enforces the policy
+// with the given nonce.
+func (p *ContentSecurityPolicy) header(nonce string) (string, string) {
+	name := "Content-Security-Policy"
+	if p.reportOnly {
+		name = "Content-Security-Policy-Report-Only"
+	}
+
+	var defaultSources []string
+	for _, d := range p.directives {
+		if d.name == "default-src" {
+			defaultSources = d.sources
+		}
+	}
+
+	var b strings.Builder
+	write := func(directive string, sources []string) {
+		if b.Len() != 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(directive)
+		for _, s := range sources {
+			b.WriteByte(' ')
+			b.WriteString(s)
+		}
+	}
+
+	allowStyleAttrs := false
+	directives := p.directives
+	for _, name := range []string{"script-src", "style-src"} {
+		if !p.hasDirective(name) {
+			// Without their own directive, scripts and styles follow the
+			// default-src one, which does not allow the nonce.
+			directives = append(directives, cspDirective{
+				name:    name,
+				sources: defaultSources,
+			})
+		}
+	}
+
+	for _, d := range directives {
+		sources := d.sources
+
+		switch d.name {
+		case "script-src":
+			if len(sources) == 0 && defaultSources == nil {
+				continue
+			}
+			sources = append(sources[:len(sources):len(sources)], "'nonce-"+nonce+"'")
+			if !containsString(sources, "'wasm-unsafe-eval'") && !containsString(sources, "'unsafe-eval'") {
+				sources = append(sources, "'wasm-unsafe-eval'")
+			}
+
+		case "style-src":
+			if len(sources) == 0 && defaultSources == nil {
+				continue
+			}
+			// Browsers ignore 'unsafe-inline' when a nonce is allowed.
+			if containsString(sources, "'unsafe-inline'") {
+				break
+			}
+			sources = append(sources[:len(sources):len(sources)], "'nonce-"+nonce+"'")
+			allowStyleAttrs = !p.hasDirective("style-src-attr")
+		}
+
+		write(d.name, sources)
+	}
+
+	if allowStyleAttrs {
+		// Style attributes can not have a nonce. They are allowed in order to
+		// not block the pre-rendered Style() and Styles() values.
+		write("style-src-attr", []string{"'unsafe-inline'"})
+	}
+
+	return name, b.String()
+}
+
+func (p *ContentSecurityPolicy) hasDirective(name string) bool {
+	for _, d := range p.directives {
+		if d.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newCSPNonce returns a random base64 encoded nonce.
+func newCSPNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.New("generating content security policy nonce failed").Wrap(err))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+var (
+	nonceableTags = regexp.MustCompile(`(?i)<(script|style|link)([\s/][^>]*)?>`)
+	nonceAttrs    = regexp.MustCompile(`(?i)\snonce\s*=`)
+)
+
+// setNonce returns the given HTML with the given nonce set on its script,
+// style and link elements that do not already have one.
+func setNonce(html, nonce string) string {
+	return nonceableTags.ReplaceAllStringFunc(html, func(tag string) string {
+		if nonceAttrs.MatchString(tag) {
+			return tag
+		}
+
+		i := strings.IndexAny(tag, " \t\n\r\f/>")
+		return tag[:i] + ` nonce="` + nonce + `"` + tag[i:]
+	})
+}
+
+func containsString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
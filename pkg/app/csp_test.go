@@ -0,0 +1,128 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentSecurityPolicyHeader(t *testing.T) {
+	utests := []struct {
+		scenario      string
+		policy        *ContentSecurityPolicy
+		expectedName  string
+		expectedValue string
+	}{
+		{
+			scenario:      "empty",
+			policy:        CSP(),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "",
+		},
+		{
+			scenario:      "default source",
+			policy:        CSP().DefaultSrc("'self'"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "default-src 'self'; script-src 'self' 'nonce-42' 'wasm-unsafe-eval'; style-src 'self' 'nonce-42'; style-src-attr 'unsafe-inline'",
+		},
+		{
+			scenario: "script and style sources",
+			policy: CSP().
+				DefaultSrc("'self'").
+				ScriptSrc("'self'", "https://cdn.example.com").
+				StyleSrc("'self'").
+				ImgSrc("'self'", "data:"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "default-src 'self'; script-src 'self' https://cdn.example.com 'nonce-42' 'wasm-unsafe-eval'; style-src 'self' 'nonce-42'; img-src 'self' data:; style-src-attr 'unsafe-inline'",
+		},
+		{
+			scenario: "style source with unsafe inline",
+			policy: CSP().
+				DefaultSrc("'self'").
+				StyleSrc("'self'", "'unsafe-inline'"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "default-src 'self'; style-src 'self' 'unsafe-inline'; script-src 'self' 'nonce-42' 'wasm-unsafe-eval'",
+		},
+		{
+			scenario:      "default source with unsafe inline",
+			policy:        CSP().DefaultSrc("'self'", "'unsafe-inline'"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "default-src 'self' 'unsafe-inline'; script-src 'self' 'unsafe-inline' 'nonce-42' 'wasm-unsafe-eval'; style-src 'self' 'unsafe-inline'",
+		},
+		{
+			scenario: "style attribute source",
+			policy: CSP().
+				DefaultSrc("'self'").
+				Directive("style-src-attr", "'none'"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "default-src 'self'; style-src-attr 'none'; script-src 'self' 'nonce-42' 'wasm-unsafe-eval'; style-src 'self' 'nonce-42'",
+		},
+		{
+			scenario:      "script source with unsafe eval",
+			policy:        CSP().ScriptSrc("'self'", "'unsafe-eval'"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "script-src 'self' 'unsafe-eval' 'nonce-42'",
+		},
+		{
+			scenario:      "directive added twice",
+			policy:        CSP().ConnectSrc("'self'").Directive("Connect-Src", "https://api.example.com"),
+			expectedName:  "Content-Security-Policy",
+			expectedValue: "connect-src 'self' https://api.example.com",
+		},
+		{
+			scenario: "report only",
+			policy: CSP().
+				FontSrc("'self'").
+				ReportURI("/csp-reports").
+				ReportOnly(true),
+			expectedName:  "Content-Security-Policy-Report-Only",
+			expectedValue: "font-src 'self'; report-uri /csp-reports",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			name, value := u.policy.header("42")
+			require.Equal(t, u.expectedName, name)
+			require.Equal(t, u.expectedValue, value)
+		})
+	}
+}
+
+func TestContentSecurityPolicyHeaderDoesNotAlterPolicy(t *testing.T) {
+	sources := make([]string, 1, 10)
+	sources[0] = "'self'"
+	policy := CSP().ScriptSrc(sources...)
+
+	_, value := policy.header("42")
+	require.Equal(t, "script-src 'self' 'nonce-42' 'wasm-unsafe-eval'", value)
+
+	_, value = policy.header("21")
+	require.Equal(t, "script-src 'self' 'nonce-21' 'wasm-unsafe-eval'", value)
+}
+
+func TestNewCSPNonce(t *testing.T) {
+	nonce := newCSPNonce()
+	require.Len(t, nonce, 24)
+	require.NotEqual(t, nonce, newCSPNonce())
+}
+
+func TestSetNonce(t *testing.T) {
+	html := `<script async src="https://example.com/a.js"></script>
+<SCRIPT>alert(1)</SCRIPT>
+<style>p{}</style>
+<link rel="stylesheet" href="/a.css"/>
+<link/>
+<script nonce="21">alert(2)</script>
+<style NONCE = "21">p{}</style>
+<scripted></scripted>`
+
+	require.Equal(t, `<script nonce="42" async src="https://example.com/a.js"></script>
+<SCRIPT nonce="42">alert(1)</SCRIPT>
+<style nonce="42">p{}</style>
+<link nonce="42" rel="stylesheet" href="/a.css"/>
+<link nonce="42"/>
+<script nonce="21">alert(2)</script>
+<style NONCE = "21">p{}</style>
+<scripted></scripted>`, setNonce(html, "42"))
+}
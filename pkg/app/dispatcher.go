@@ -58,7 +58,11 @@ type Dispatcher interface {
 	Wait()
 
 	start(context.Context)
+	asyncFrom(src UI, fn func())
+	getBaseContext() context.Context
+	getBasePath() string
 	getCurrentPage() Page
+	getLoaderResult() *loaderResult
 	getLocalStorage() BrowserStorage
 	getSessionStorage() BrowserStorage
 	isServerSide() bool
@@ -96,6 +100,12 @@ type ClientDispatcher interface {
 
 	// Triggers OnAppResize from the root component.
 	AppResize()
+
+	mountRoute([]Composer, *loaderResult)
+	leaveRoute(route []Composer, next func())
+	enterRoute()
+	shareTarget(ShareTargetData)
+	launchFiles([]SharedFile)
 }
 
 // NewClientTester creates a testing dispatcher that simulates a
@@ -2,6 +2,8 @@ package app
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestElemMountDismount(t *testing.T) {
@@ -164,3 +166,152 @@ func TestElemUpdate(t *testing.T) {
 		},
 	})
 }
+
+func TestElemUpdateKeyedChildren(t *testing.T) {
+	t.Run("keyed children are moved", func(t *testing.T) {
+		a := Div().Body(
+			Li().Key(1).Text("1"),
+			Li().Key(2).Text("2"),
+			Li().Key(3).Text("3"),
+		)
+		d := NewClientTester(a)
+		defer d.Close()
+
+		children := a.getChildren()
+		first := children[0]
+		second := children[1]
+		third := children[2]
+
+		err := update(a, Div().Body(
+			Li().Key(0).Text("0"),
+			Li().Key(3).Text("3"),
+			Li().Key(1).Text("one"),
+		))
+		require.NoError(t, err)
+		d.Consume()
+
+		children = a.getChildren()
+		require.Len(t, children, 3)
+		require.Equal(t, 0, children[0].getKey())
+		require.True(t, children[0].Mounted())
+		require.Equal(t, third, children[1])
+		require.Equal(t, first, children[2])
+		require.NoError(t, TestMatch(a, TestUIDescriptor{
+			Path:     TestPath(2, 0),
+			Expected: Text("one"),
+		}))
+		require.False(t, second.Mounted())
+	})
+
+	t.Run("keyed components keep their state", func(t *testing.T) {
+		a := Div().Body(
+			Keyed("a", &bar{Value: "a"}),
+			Keyed("b", &bar{Value: "b"}),
+		)
+		d := NewClientTester(a)
+		defer d.Close()
+
+		compoA := a.getChildren()[0].(*bar)
+		compoB := a.getChildren()[1].(*bar)
+
+		err := update(a, Div().Body(
+			Keyed("c", &bar{Value: "c"}),
+			Keyed("a", &bar{Value: "a"}),
+			Keyed("b", &bar{Value: "b"}),
+		))
+		require.NoError(t, err)
+		d.Consume()
+
+		children := a.getChildren()
+		require.Len(t, children, 3)
+		require.Equal(t, "c", children[0].(*bar).Value)
+		require.Equal(t, compoA, children[1])
+		require.Equal(t, compoB, children[2])
+		require.True(t, compoA.Mounted())
+		require.True(t, compoB.Mounted())
+		require.False(t, compoA.updated)
+		require.False(t, compoB.updated)
+	})
+
+	t.Run("unkeyed children are matched by position", func(t *testing.T) {
+		a := Div().Body(
+			H1().Text("title"),
+			P().Key("p").Text("p"),
+		)
+		d := NewClientTester(a)
+		defer d.Close()
+
+		title := a.getChildren()[0]
+
+		err := update(a, Div().Body(
+			Span().Key("span"),
+			H1().Text("new title"),
+		))
+		require.NoError(t, err)
+		d.Consume()
+
+		children := a.getChildren()
+		require.Len(t, children, 2)
+		require.Equal(t, title, children[1])
+		require.NoError(t, TestMatch(a, TestUIDescriptor{
+			Path:     TestPath(1, 0),
+			Expected: Text("new title"),
+		}))
+	})
+
+	t.Run("children with duplicate keys are matched by position", func(t *testing.T) {
+		a := Div().Body(
+			Li().Key(1).Text("a"),
+			Li().Key(1).Text("b"),
+			Li().Key(2).Text("c"),
+		)
+		d := NewClientTester(a)
+		defer d.Close()
+
+		children := a.getChildren()
+		first := children[0]
+		second := children[1]
+		third := children[2]
+
+		err := update(a, Div().Body(
+			Li().Key(1).Text("x"),
+			Li().Key(1).Text("y"),
+			Li().Key(2).Text("z"),
+		))
+		require.NoError(t, err)
+		d.Consume()
+
+		children = a.getChildren()
+		require.Len(t, children, 3)
+		require.Equal(t, first, children[0])
+		require.Equal(t, second, children[1])
+		require.Equal(t, third, children[2])
+		require.NoError(t, TestMatch(a, TestUIDescriptor{
+			Path:     TestPath(0, 0),
+			Expected: Text("x"),
+		}))
+		require.NoError(t, TestMatch(a, TestUIDescriptor{
+			Path:     TestPath(1, 0),
+			Expected: Text("y"),
+		}))
+	})
+
+	t.Run("element with a different key is replaced", func(t *testing.T) {
+		a := Div().Body(
+			P().Key(1),
+		)
+		d := NewClientTester(a)
+		defer d.Close()
+
+		p := a.getChildren()[0]
+
+		err := update(a, Div().Body(
+			P().Key(2),
+		))
+		require.NoError(t, err)
+		d.Consume()
+
+		require.NotEqual(t, p, a.getChildren()[0])
+		require.False(t, p.Mounted())
+	})
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"net/url"
+	"reflect"
 	"sort"
 	"sync"
 	"time"
@@ -32,6 +33,18 @@ type engine struct {
 	// The body of the page.
 	Body HTMLBody
 
+	// The URL path prefix under which the app is mounted.
+	BasePath string
+
+	// The context that the contexts of the mounted elements derive from.
+	//
+	// Default is context.Background().
+	BaseContext context.Context
+
+	// Reports whether the first mounted element reuses the server-side
+	// pre-rendered DOM nodes of the body rather than creating new ones.
+	Hydrate bool
+
 	// The action handlers that are not associated with a component and are
 	// executed asynchronously.
 	ActionHandlers map[string]ActionHandler
@@ -39,6 +52,7 @@ type engine struct {
 	initOnce             sync.Once
 	startOnce            sync.Once
 	closeOnce            sync.Once
+	closed               chan struct{}
 	wait                 sync.WaitGroup
 	componentUpdateMutex sync.RWMutex
 
@@ -49,6 +63,10 @@ type engine struct {
 	actions              actionManager
 	states               *store
 	isFirstMount         bool
+	route                []Composer
+	enteredRouteIndex    int
+	loaded               *loaderResult
+	asyncs               *asyncTracker
 }
 
 func (e *engine) Context() Context {
@@ -59,7 +77,11 @@ func (e *engine) Dispatch(d Dispatch) {
 	if d.Source == nil {
 		d.Source = e.Body
 	}
-	e.dispatches <- d
+
+	select {
+	case e.dispatches <- d:
+	case <-e.closed:
+	}
 }
 
 func (e *engine) Emit(src UI, fn func()) {
@@ -113,9 +135,20 @@ func (e *engine) ObserveState(state string, elem UI) Observer {
 }
 
 func (e *engine) Async(fn func()) {
+	e.asyncFrom(nil, fn)
+}
+
+func (e *engine) asyncFrom(src UI, fn func()) {
 	e.wait.Add(1)
+	if e.asyncs != nil {
+		e.asyncs.start(src)
+	}
+
 	go func() {
 		fn()
+		if e.asyncs != nil {
+			e.asyncs.finish(src, e.getBaseContext().Err() != nil)
+		}
 		e.wait.Done()
 	}()
 }
@@ -139,6 +172,27 @@ func (e *engine) Consume() {
 	}
 }
 
+// consumeReady executes the UI instructions that are ready, without waiting
+// for the asynchronous operations.
+func (e *engine) consumeReady() {
+	for {
+		for len(e.dispatches) != 0 {
+			e.handleDispatch(<-e.dispatches)
+		}
+		e.handleFrame()
+
+		if len(e.dispatches) == 0 {
+			return
+		}
+	}
+}
+
+// waitContext waits for the asynchronous operations launched with Async() to
+// complete. It returns false when the given context is done first.
+func (e *engine) waitContext(ctx context.Context) bool {
+	return e.asyncs.wait(ctx)
+}
+
 func (e *engine) ConsumeNext() {
 	e.Wait()
 	e.handleDispatch(<-e.dispatches)
@@ -149,41 +203,233 @@ func (e *engine) Close() {
 	e.closeOnce.Do(func() {
 		e.Consume()
 		e.Wait()
+		e.release()
+	})
+}
 
-		dismount(e.Body)
-		e.Body = nil
-		e.states.Close()
+// abort closes the engine without waiting for the asynchronous operations
+// that are still running. The UI instructions that they dispatch once they
+// return are dropped.
+func (e *engine) abort() {
+	e.closeOnce.Do(func() {
+		e.asyncs.stop()
+		e.release()
 	})
 }
 
+func (e *engine) release() {
+	close(e.closed)
+	dismount(e.Body)
+	e.Body = nil
+	e.states.Close()
+}
+
 func (e *engine) Mount(v UI) {
 	e.Dispatch(Dispatch{
 		Mode:   Update,
 		Source: e.Body,
 		Function: func(ctx Context) {
-			if e.isFirstMount {
-				if err := e.Body.(*htmlBody).replaceChildAt(0, v); err != nil {
-					panic(errors.New("mounting first ui element failed").Wrap(err))
+			e.route = nil
+			e.mountRoot(v)
+		},
+	})
+}
+
+// mountRoute mounts the given layouts and routed component, with the result of
+// the route loader. The mounted layouts that are shared with the previous route
+// are kept and only have their outlet content replaced.
+func (e *engine) mountRoute(route []Composer, loaded *loaderResult) {
+	e.Dispatch(Dispatch{
+		Mode:   Update,
+		Source: e.Body,
+		Function: func(ctx Context) {
+			defer e.recoverRoute()
+			e.loaded = loaded
+
+			i := e.sharedRouteLen(route)
+			copy(route, e.route[:i])
+			e.enteredRouteIndex = i
+
+			for j := 0; j < len(route)-1; j++ {
+				route[j].setRouted(route[j+1])
+			}
+
+			if i == 0 {
+				if c, ok := e.mountRoot(route[0]).(Composer); ok {
+					route[0] = c
 				}
+				e.route = route
+				return
+			}
 
-				e.isFirstMount = false
+			outlet := findOutlet(route[i-1])
+			if outlet == nil {
+				panic(errors.New("mounting route failed").
+					WithTag("reason", "layout does not render an outlet").
+					WithTag("layout", route[i-1].name()))
+			}
+
+			c, err := outlet.setChild(route[i])
+			if err != nil {
+				panic(errors.New("mounting route failed").
+					WithTag("layout", route[i-1].name()).
+					Wrap(err))
+			}
+			route[i] = c
+			route[i-1].setRouted(c)
+			e.route = route
+		},
+	})
+}
+
+// sharedRouteLen returns the number of mounted layouts that are shared by the
+// current route and the given one.
+func (e *engine) sharedRouteLen(route []Composer) int {
+	i := 0
+	for i < len(route)-1 &&
+		i < len(e.route)-1 &&
+		e.route[i].Mounted() &&
+		reflect.TypeOf(e.route[i]) == reflect.TypeOf(route[i]) {
+		i++
+	}
+	return i
+}
+
+// leaveRoute calls OnBeforeLeave on the components of the current route that
+// are not shared with the given one, then calls next once they all have
+// called their leave function.
+func (e *engine) leaveRoute(route []Composer, next func()) {
+	e.Dispatch(Dispatch{
+		Mode:   Update,
+		Source: e.Body,
+		Function: func(ctx Context) {
+			var leavers []NavLeaver
+			if i := e.sharedRouteLen(route); i < len(e.route) && e.route[i].Mounted() {
+				forEachComponent(e.route[i], func(c Composer) {
+					if l, ok := c.(NavLeaver); ok {
+						leavers = append(leavers, l)
+					}
+				})
+			}
+			if len(leavers) == 0 {
+				next()
 				return
 			}
 
-			if firstChild := e.Body.getChildren()[0]; canUpdate(firstChild, v) {
-				if err := update(firstChild, v); err != nil {
-					panic(errors.New("mounting ui element failed").Wrap(err))
+			remaining := len(leavers)
+			for _, l := range leavers {
+				l := l
+				var once sync.Once
+
+				leave := func() {
+					once.Do(func() {
+						e.Dispatch(Dispatch{
+							Mode:   Update,
+							Source: e.Body,
+							Function: func(ctx Context) {
+								if remaining--; remaining == 0 {
+									next()
+								}
+							},
+						})
+					})
 				}
-				return
+
+				l.dispatch(func(ctx Context) {
+					l.OnBeforeLeave(ctx, leave)
+				})
 			}
+		},
+	})
+}
 
-			if err := e.Body.(*htmlBody).replaceChildAt(0, v); err != nil {
-				panic(errors.New("mounting ui element failed").Wrap(err))
+// enterRoute calls OnAfterEnter on the components of the current route that
+// have been mounted by the last mounted route.
+func (e *engine) enterRoute() {
+	e.Dispatch(Dispatch{
+		Mode:   Defer,
+		Source: e.Body,
+		Function: func(ctx Context) {
+			if e.enteredRouteIndex >= len(e.route) {
+				return
 			}
+
+			forEachComponent(e.route[e.enteredRouteIndex], func(c Composer) {
+				if en, ok := c.(NavEnterer); ok {
+					c.dispatch(en.OnAfterEnter)
+				}
+			})
 		},
 	})
 }
 
+// recoverRoute mounts the error page when mounting a route panics on the
+// client. On the server, the panic is propagated in order to respond with the
+// error page status.
+func (e *engine) recoverRoute() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if e.IsServerSide {
+		panic(r)
+	}
+
+	err := recoveredError("mounting route failed", r)
+	route, ok := routes.createErrorRoute(err)
+	if !ok {
+		panic(r)
+	}
+	Log(err)
+
+	e.route = nil
+	e.loaded = nil
+	e.mountRoot(route[0])
+}
+
+// recoveredError returns an error with the given message that describes the
+// given recovered panic value.
+func recoveredError(msg string, v any) error {
+	if err, ok := v.(error); ok {
+		return errors.New(msg).Wrap(err)
+	}
+	return errors.New(msg).WithTag("panic", v)
+}
+
+func (e *engine) mountRoot(v UI) UI {
+	body := e.Body.(*htmlBody)
+
+	if e.isFirstMount && e.Hydrate {
+		if err := body.hydrateChildAt(0, v, isAppWasmLoader); err != nil {
+			panic(errors.New("hydrating first ui element failed").Wrap(err))
+		}
+
+		e.isFirstMount = false
+		return v
+	}
+
+	if e.isFirstMount {
+		if err := body.replaceChildAt(0, v); err != nil {
+			panic(errors.New("mounting first ui element failed").Wrap(err))
+		}
+
+		e.isFirstMount = false
+		return v
+	}
+
+	if firstChild := body.getChildren()[0]; canUpdate(firstChild, v) {
+		if err := update(firstChild, v); err != nil {
+			panic(errors.New("mounting ui element failed").Wrap(err))
+		}
+		return firstChild
+	}
+
+	if err := body.replaceChildAt(0, v); err != nil {
+		panic(errors.New("mounting ui element failed").Wrap(err))
+	}
+	return v
+}
+
 func (e *engine) Nav(u *url.URL) {
 	if p, ok := e.Page.(*requestPage); ok {
 		p.ReplaceURL(u)
@@ -228,6 +474,26 @@ func (e *engine) AppResize() {
 	})
 }
 
+func (e *engine) shareTarget(data ShareTargetData) {
+	e.Dispatch(Dispatch{
+		Mode:   Update,
+		Source: e.Body,
+		Function: func(ctx Context) {
+			ctx.Src().onComponentEvent(shareTarget{data: data})
+		},
+	})
+}
+
+func (e *engine) launchFiles(files []SharedFile) {
+	e.Dispatch(Dispatch{
+		Mode:   Update,
+		Source: e.Body,
+		Function: func(ctx Context) {
+			ctx.Src().onComponentEvent(fileLaunch{files: files})
+		},
+	})
+}
+
 func (e *engine) init() {
 	e.initOnce.Do(func() {
 		if e.FrameRate <= 0 {
@@ -262,18 +528,27 @@ func (e *engine) init() {
 		}
 
 		e.dispatches = make(chan Dispatch, 4096)
+		e.closed = make(chan struct{})
 		e.componentUpdates = make(map[Composer]bool)
 		e.componentUpdateQueue = make([]componentUpdate, 0, 32)
 		e.deferables = make([]Dispatch, 32)
 		e.states = newStore(e)
 		e.isFirstMount = true
 
+		if e.asyncs == nil {
+			e.asyncs = newAsyncTracker(false)
+		}
+
 		for actionName, handler := range e.ActionHandlers {
 			e.actions.handle(actionName, true, e.Body, handler)
 		}
 	})
 }
 
+func (e *engine) getLoaderResult() *loaderResult {
+	return e.loaded
+}
+
 func (e *engine) getCurrentPage() Page {
 	return e.Page
 }
@@ -286,6 +561,17 @@ func (e *engine) getSessionStorage() BrowserStorage {
 	return e.SessionStorage
 }
 
+func (e *engine) getBaseContext() context.Context {
+	if e.BaseContext == nil {
+		return context.Background()
+	}
+	return e.BaseContext
+}
+
+func (e *engine) getBasePath() string {
+	return e.BasePath
+}
+
 func (e *engine) isServerSide() bool {
 	return e.IsServerSide
 }
@@ -359,15 +645,33 @@ func (e *engine) start(ctx context.Context) {
 func (e *engine) handleDispatch(d Dispatch) {
 	switch d.Mode {
 	case Update:
-		d.do()
+		e.do(d)
 		e.addComponentUpdate(getComponent(d.Source))
 
 	case Defer:
 		e.deferables = append(e.deferables, d)
 
 	case Next:
+		e.do(d)
+	}
+}
+
+// do executes the given dispatch. A panic that occurs during the execution is
+// caught by the nearest error boundary of the dispatch source, if any. Panics
+// are not recovered when there is no error boundary.
+func (e *engine) do(d Dispatch) {
+	if d.Source == nil || !hasErrorBoundary(d.Source) {
 		d.do()
+		return
 	}
+
+	defer func() {
+		if r := recover(); r != nil && !catchError(d.Source, panicError(r)) {
+			repanic(r)
+		}
+	}()
+
+	d.do()
 }
 
 func (e *engine) handleFrame() {
@@ -399,7 +703,7 @@ func (e *engine) handleComponentUpdates() {
 			continue
 		}
 
-		if err := u.component.updateRoot(); err != nil {
+		if err := e.updateComponent(u.component); err != nil {
 			panic(err)
 		}
 		e.removeComponentUpdate(u.component)
@@ -409,9 +713,32 @@ func (e *engine) handleComponentUpdates() {
 	e.componentUpdateQueue = e.componentUpdateQueue[:0]
 }
 
+// updateComponent updates the given component. Errors and panics that occur
+// during the update are caught by its nearest error boundary, if any.
+func (e *engine) updateComponent(c Composer) (err error) {
+	if !hasErrorBoundary(c) {
+		return c.updateRoot()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if !catchError(c, panicError(r)) {
+				repanic(r)
+			}
+			return
+		}
+
+		if err != nil && catchError(c, err) {
+			err = nil
+		}
+	}()
+
+	return c.updateRoot()
+}
+
 func (e *engine) handleDeferables() {
 	for i := range e.deferables {
-		e.deferables[i].do()
+		e.do(e.deferables[i])
 		e.deferables[i] = Dispatch{}
 	}
 	e.deferables = e.deferables[:0]
@@ -1,7 +1,9 @@
 package app
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +39,49 @@ func TestEngineDispatch(t *testing.T) {
 	require.Equal(t, e.Body, d.Source)
 }
 
+func TestEngineWaitContext(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	release := make(chan struct{})
+	e.Async(func() {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	require.False(t, e.waitContext(ctx))
+
+	close(release)
+	require.True(t, e.waitContext(context.Background()))
+}
+
+func TestEngineAbort(t *testing.T) {
+	e := engine{asyncs: newAsyncTracker(true)}
+	e.init()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	e.Async(func() {
+		<-release
+		e.Dispatch(Dispatch{})
+		close(done)
+	})
+
+	start := time.Now()
+	e.abort()
+	require.True(t, time.Since(start) < time.Second)
+	require.Nil(t, e.Body)
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "asynchronous operation is blocked after abort")
+	}
+}
+
 func TestEngineEmit(t *testing.T) {
 	e := engine{}
 	e.init()
@@ -248,3 +293,63 @@ func TestEngineHandlePost(t *testing.T) {
 	require.True(t, isHandleBCalled)
 	require.False(t, isHandleCCalled)
 }
+
+type transitionTestCompo struct {
+	Compo
+
+	leave   func()
+	entered bool
+}
+
+func (c *transitionTestCompo) OnBeforeLeave(ctx Context, leave func()) {
+	c.leave = leave
+}
+
+func (c *transitionTestCompo) OnAfterEnter(ctx Context) {
+	c.entered = true
+}
+
+func (c *transitionTestCompo) Render() UI {
+	return Div()
+}
+
+type otherTransitionTestCompo struct {
+	transitionTestCompo
+}
+
+func TestEngineRouteTransitions(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	layout := &layoutTestCompo{}
+	page := &transitionTestCompo{}
+	next := func(route []Composer) {
+		e.leaveRoute(route, func() {
+			e.mountRoute(route, nil)
+			e.enterRoute()
+		})
+		e.Consume()
+		e.Consume()
+	}
+
+	next([]Composer{layout, page})
+	require.True(t, page.Mounted())
+	require.True(t, page.entered)
+	require.Nil(t, page.leave)
+
+	newPage := &otherTransitionTestCompo{}
+	next([]Composer{&layoutTestCompo{}, newPage})
+	require.NotNil(t, page.leave)
+	require.True(t, page.Mounted())
+	require.False(t, newPage.Mounted())
+
+	page.leave()
+	page.leave()
+	e.Consume()
+	e.Consume()
+	require.False(t, page.Mounted())
+	require.True(t, newPage.Mounted())
+	require.True(t, newPage.entered)
+	require.True(t, layout.Mounted())
+}
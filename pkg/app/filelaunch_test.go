@@ -0,0 +1,98 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fileLaunchTestCompo struct {
+	Compo
+
+	files []SharedFile
+}
+
+func (c *fileLaunchTestCompo) OnFileLaunch(ctx Context, files []SharedFile) {
+	c.files = files
+}
+
+func (c *fileLaunchTestCompo) Render() UI {
+	return Div()
+}
+
+func TestOnFileLaunch(t *testing.T) {
+	testSkipNonWasm(t)
+
+	compo := &fileLaunchTestCompo{}
+	client := NewClientTester(compo)
+	defer client.Close()
+
+	newHandle := func(file func() Value) (Value, Func) {
+		getFile := FuncOf(func(this Value, args []Value) any {
+			return file()
+		})
+
+		handle := Window().Get("Object").New()
+		handle.Set("getFile", getFile)
+		return handle, getFile
+	}
+
+	notes, releaseNotes := newHandle(func() Value {
+		file := Window().Get("File").New(
+			[]any{"hello"},
+			"notes.txt",
+			map[string]any{"type": "text/plain"},
+		)
+		return Window().Get("Promise").Call("resolve", file)
+	})
+	defer releaseNotes.Release()
+
+	denied, releaseDenied := newHandle(func() Value {
+		return Window().Get("Promise").Call("reject", Window().Get("Error").New("denied"))
+	})
+	defer releaseDenied.Release()
+
+	params := Window().Get("Object").New()
+	params.Set("files", Window().Get("Array").New(denied, notes))
+	onFileLaunch(client)(nil, []Value{params})
+
+	for i := 0; i < 100 && compo.files == nil; i++ {
+		time.Sleep(time.Millisecond * 10)
+		client.Consume()
+	}
+	require.Equal(t, []SharedFile{
+		{
+			Name: "notes.txt",
+			Type: "text/plain",
+			Size: 5,
+			Data: []byte("hello"),
+		},
+	}, compo.files)
+}
+
+func TestReadPreRenderedShareTargetData(t *testing.T) {
+	testSkipNonWasm(t)
+
+	_, ok := readPreRenderedShareTargetData()
+	require.False(t, ok)
+
+	script, err := Window().createElement("script", "")
+	require.NoError(t, err)
+	script.Set("id", shareTargetDataID)
+	script.Set("type", "application/json")
+	script.Set("textContent", `{"title":"notes","files":[{"name":"notes.txt","type":"text/plain","size":5}]}`)
+
+	body := Window().Get("document").Get("body")
+	body.Call("appendChild", script)
+	defer body.Call("removeChild", script)
+
+	data, ok := readPreRenderedShareTargetData()
+	require.True(t, ok)
+	require.Equal(t, ShareTargetData{
+		Title: "notes",
+		Files: []SharedFile{
+			{Name: "notes.txt", Type: "text/plain", Size: 5},
+		},
+	}, data)
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// The Cache-Control header value of the resources that are served from a
+	// fingerprinted URL. Since the URL changes with the resource content, the
+	// resource can be cached forever.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+)
+
+// contentHash returns a short hexadecimal hash of the given content.
+func contentHash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// readerHash returns the same hash as contentHash for the content read from
+// the given reader, without loading it in memory.
+func readerHash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8]), nil
+}
+
+// fingerprintPath returns the given path with the hash of the given content
+// inserted before its extension, eg: "/app.js" becomes "/app.1a2b3c4d5e6f7a8b.js".
+func fingerprintPath(p string, content []byte) string {
+	ext := path.Ext(p)
+	return strings.TrimSuffix(p, ext) + "." + contentHash(content) + ext
+}
+
+// addFingerprint registers the fingerprinted path of the resource located at
+// the given path. It must only be called when the handler is initialized.
+func (h *Handler) addFingerprint(path string, content []byte) {
+	if h.fingerprints == nil {
+		h.fingerprints = make(map[string]string)
+		h.fingerprintedPaths = make(map[string]string)
+	}
+
+	fingerprinted := fingerprintPath(path, content)
+	h.fingerprints[path] = fingerprinted
+	h.fingerprintedPaths[fingerprinted] = path
+}
+
+// fingerprint returns the fingerprinted version of the given resource path. The
+// path is returned as it is when the resource is not fingerprinted.
+func (h *Handler) fingerprint(path string) string {
+	if fingerprinted, ok := h.fingerprints[path]; ok {
+		return fingerprinted
+	}
+	return path
+}
+
+// addLocalStaticResourceFingerprint registers the fingerprinted path of the
+// given static resource when it is located in a local directory.
+func (h *Handler) addLocalStaticResourceFingerprint(src string) {
+	url, _, _ := parseSrc(src)
+	if isRemoteLocation(url) || !isStaticResourcePath(url) {
+		return
+	}
+
+	path := "/" + strings.TrimPrefix(url, "/")
+	if _, ok := h.fingerprints[path]; ok {
+		return
+	}
+
+	if b, err := h.readLocalFile(path); err == nil && b != nil {
+		h.addFingerprint(path, b)
+	}
+}
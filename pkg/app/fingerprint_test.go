@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintPath(t *testing.T) {
+	hash := contentHash([]byte("hello"))
+	require.Len(t, hash, 16)
+	require.Equal(t, "/app."+hash+".js", fingerprintPath("/app.js", []byte("hello")))
+	require.Equal(t, "/web/app."+hash+".wasm", fingerprintPath("/web/app.wasm", []byte("hello")))
+	require.Equal(t, "/web/LICENSE."+hash, fingerprintPath("/web/LICENSE", []byte("hello")))
+	require.NotEqual(t, fingerprintPath("/app.js", []byte("hello")), fingerprintPath("/app.js", []byte("world")))
+}
+
+func TestHandlerFingerprint(t *testing.T) {
+	h := Handler{}
+	require.Equal(t, "/app.js", h.fingerprint("/app.js"))
+
+	h.addFingerprint("/app.js", []byte("hello"))
+	fingerprinted := h.fingerprint("/app.js")
+	require.Equal(t, fingerprintPath("/app.js", []byte("hello")), fingerprinted)
+	require.Equal(t, "/app.js", h.fingerprintedPaths[fingerprinted])
+}
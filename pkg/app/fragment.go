@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// Frag returns a fragment that groups the given elements without wrapping them
+// into an HTML element. Its elements are displayed as siblings within the
+// nearest parent HTML element.
+//
+// It is useful for components that render several elements, or for elements
+// such as table rows and grid items that cannot be wrapped.
+//
+// A mounted fragment is linked to an empty text node that marks its end in the
+// DOM. It is the value returned by its JSValue method.
+func Frag(elems ...UI) UI {
+	return &fragment{children: FilterUIElems(elems...)}
+}
+
+type fragment struct {
+	key        any
+	parentElem UI
+	children   []UI
+
+	context       context.Context
+	contextCancel func()
+	dispatcher    Dispatcher
+	jsAnchor      Value
+}
+
+func (f *fragment) Kind() Kind {
+	return Fragment
+}
+
+func (f *fragment) JSValue() Value {
+	return f.jsAnchor
+}
+
+func (f *fragment) Mounted() bool {
+	return f.context != nil && f.context.Err() == nil
+}
+
+func (f *fragment) name() string {
+	return "fragment"
+}
+
+func (f *fragment) self() UI {
+	return f
+}
+
+func (f *fragment) setSelf(UI) {
+}
+
+func (f *fragment) getContext() context.Context {
+	return f.context
+}
+
+func (f *fragment) getDispatcher() Dispatcher {
+	return f.dispatcher
+}
+
+func (f *fragment) getAttributes() attributes {
+	return nil
+}
+
+func (f *fragment) getEventHandlers() eventHandlers {
+	return nil
+}
+
+func (f *fragment) getKey() any {
+	return f.key
+}
+
+func (f *fragment) setKey(v any) {
+	checkKey(v)
+	f.key = v
+}
+
+func (f *fragment) getParent() UI {
+	return f.parentElem
+}
+
+func (f *fragment) setParent(p UI) {
+	f.parentElem = p
+}
+
+func (f *fragment) getChildren() []UI {
+	return f.children
+}
+
+func (f *fragment) mount(d Dispatcher) error {
+	if f.Mounted() {
+		return errors.New("mounting fragment failed").
+			WithTag("reason", "already mounted").
+			WithTag("name", f.name()).
+			WithTag("kind", f.Kind())
+	}
+
+	f.context, f.contextCancel = context.WithCancel(d.getBaseContext())
+	f.dispatcher = d
+	f.jsAnchor = Window().createTextNode("")
+
+	for i, c := range f.children {
+		if err := mount(d, c); err != nil {
+			return errors.New("mounting child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				Wrap(err)
+		}
+		c.setParent(f)
+	}
+
+	return nil
+}
+
+func (f *fragment) hydrate(d Dispatcher, c *hydrationCursor) error {
+	if f.Mounted() {
+		return f.mount(d)
+	}
+
+	f.context, f.contextCancel = context.WithCancel(d.getBaseContext())
+	f.dispatcher = d
+	f.jsAnchor = Window().createTextNode("")
+
+	for i, child := range f.children {
+		if err := hydrate(d, child, c); err != nil {
+			return errors.New("hydrating child failed").
+				WithTag("index", i).
+				WithTag("child", child.name()).
+				WithTag("child-kind", child.Kind()).
+				Wrap(err)
+		}
+		child.setParent(f)
+	}
+
+	return nil
+}
+
+func (f *fragment) dismount() {
+	if f.contextCancel == nil {
+		return
+	}
+
+	for _, c := range f.children {
+		dismount(c)
+	}
+	f.contextCancel()
+}
+
+func (f *fragment) canUpdateWith(v UI) bool {
+	return f.Mounted() &&
+		f.Kind() == v.Kind() &&
+		f.getKey() == v.getKey()
+}
+
+func (f *fragment) updateWith(v UI) error {
+	if !f.canUpdateWith(v) {
+		return errors.New("cannot update fragment with given element").
+			WithTag("current", reflect.TypeOf(f)).
+			WithTag("new", reflect.TypeOf(v))
+	}
+
+	parent, ok := jsParent(f)
+	if !ok {
+		return errors.New("updating fragment failed").
+			WithTag("reason", "fragment does not have html element parents")
+	}
+
+	children, err := updateChildren(f, parent, f.jsAnchor, f.children, v.getChildren())
+	f.children = children
+	return err
+}
+
+func (f *fragment) onComponentEvent(le any) {
+	for _, c := range f.children {
+		c.onComponentEvent(le)
+	}
+}
+
+func (f *fragment) html(w io.Writer) {
+	for i, c := range f.children {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.html(w)
+	}
+}
+
+func (f *fragment) htmlWithIndent(w io.Writer, indent int) {
+	for i, c := range f.children {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.htmlWithIndent(w, indent)
+	}
+}
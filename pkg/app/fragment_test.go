@@ -0,0 +1,176 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentMountDismount(t *testing.T) {
+	testMountDismount(t, []mountTest{
+		{
+			scenario: "fragment",
+			node: Frag(
+				H1(),
+				Text("hello"),
+			),
+		},
+		{
+			scenario: "empty fragment",
+			node:     Frag(),
+		},
+		{
+			scenario: "component with a fragment root",
+			node:     &fragmentCompo{Items: []string{"a", "b"}},
+		},
+	})
+}
+
+func TestFragmentUpdate(t *testing.T) {
+	testUpdate(t, []updateTest{
+		{
+			scenario: "fragment children are updated",
+			a: Div().Body(
+				Frag(
+					H1(),
+					Text("hello"),
+				),
+			),
+			b: Div().Body(
+				Frag(
+					H1(),
+					Text("world"),
+					P(),
+				),
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: Frag(),
+				},
+				{
+					Path:     TestPath(0, 1),
+					Expected: Text("world"),
+				},
+				{
+					Path:     TestPath(0, 2),
+					Expected: P(),
+				},
+			},
+		},
+		{
+			scenario: "fragment children are removed",
+			a: Div().Body(
+				Frag(
+					H1(),
+					Text("hello"),
+				),
+			),
+			b: Div().Body(
+				Frag(),
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: Frag(),
+				},
+				{
+					Path:     TestPath(0, 0),
+					Expected: nil,
+				},
+			},
+		},
+		{
+			scenario: "fragment is replaced by html element",
+			a: Div().Body(
+				Frag(
+					H1(),
+				),
+			),
+			b: Div().Body(
+				H2(),
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: H2(),
+				},
+			},
+		},
+		{
+			scenario: "component fragment root is updated",
+			a:        &fragmentCompo{Items: []string{"a"}},
+			b:        &fragmentCompo{Items: []string{"a", "b"}},
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: Frag(),
+				},
+				{
+					Path:     TestPath(0, 1),
+					Expected: Li(),
+				},
+				{
+					Path:     TestPath(0, 1, 0),
+					Expected: Text("b"),
+				},
+			},
+		},
+	})
+}
+
+func TestFragmentMatch(t *testing.T) {
+	tree := Ul().Body(
+		Keyed("items", Frag(
+			Li().Text("a"),
+		)),
+	)
+	d := NewClientTester(tree)
+	defer d.Close()
+
+	require.NoError(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Frag(),
+	}))
+	require.NoError(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Keyed("items", Frag()),
+	}))
+	require.Error(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Keyed("other", Frag()),
+	}))
+	require.Error(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Frag(Li().Text("b")),
+	}))
+	require.Error(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Li(),
+	}))
+}
+
+func TestFragmentHTML(t *testing.T) {
+	html := HTMLString(Ul().Body(
+		Frag(
+			Li().Text("a"),
+			Li().Text("b"),
+		),
+	))
+	require.Equal(t, "<ul>\n<li>a</li>\n<li>b</li>\n</ul>", html)
+
+	html = HTMLString(&fragmentCompo{Items: []string{"a", "b"}})
+	require.Equal(t, "<li>a</li>\n<li>b</li>", html)
+}
+
+type fragmentCompo struct {
+	Compo
+
+	Items []string
+}
+
+func (c *fragmentCompo) Render() UI {
+	return Range(c.Items).Slice(func(i int) UI {
+		return Li().Text(c.Items[i])
+	})
+}
@@ -2221,6 +2221,11 @@ func writeInterface(w io.Writer, t tag) {
 		`, t.Name)
 	}
 
+	fmt.Fprintf(w, `
+		// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+		Key(v any) HTML%s
+	`, t.Name)
+
 	for _, a := range t.Attrs {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w)
@@ -2316,6 +2321,16 @@ func writeStruct(w io.Writer, t tag) {
 		)
 	}
 
+	fmt.Fprintf(w, `
+		func (e *html%s) Key(v any) HTML%s {
+			e.setKey(v)
+			return e
+		}
+		`,
+		t.Name,
+		t.Name,
+	)
+
 	for _, a := range t.Attrs {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w)
@@ -2569,6 +2584,8 @@ import (
 			}
 		}
 
+		fmt.Fprintln(f, `elem.Key("foo")`)
+
 		if len(t.EventHandlers) != 0 {
 			fmt.Fprint(f, `
 				h := func(ctx Context, e Event) {}
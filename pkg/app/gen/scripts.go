@@ -47,10 +47,6 @@ func main() {
 			Var:      "appJS",
 			Filename: "gen/app.js",
 		},
-		{
-			Var:      "manifestJSON",
-			Filename: "gen/manifest.webmanifest",
-		},
 		{
 			Var:      "appCSS",
 			Filename: "gen/app.css",
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"net/url"
+	"strings"
+)
+
+// isHashRouting reports whether the client routes are read from and written to
+// the URL fragment, with URLs such as "https://example.com/#/users/42".
+var isHashRouting bool
+
+// toAppURL returns the URL that is routed by the app from the given browser
+// URL.
+//
+// With hash routing, the path, the query and the fragment of the returned URL
+// are read from the browser URL fragment, where the route fragment separator
+// is escaped as "%23". Fragments that do not start with a "/" are considered as
+// anchors within the current page.
+func toAppURL(u *url.URL) *url.URL {
+	if !isHashRouting || u == nil {
+		return u
+	}
+
+	rootPath := rootPrefix + "/"
+	if u.Path != "" && u.Path != rootPath && u.Path != rootPrefix && u.Path != rootPath+"index.html" {
+		return u
+	}
+
+	appURL := *u
+	if !strings.HasPrefix(u.Fragment, "/") {
+		appURL.Path = rootPath
+		if u.Fragment != "" && lastURLVisited != nil {
+			appURL.Path = lastURLVisited.Path
+			appURL.RawQuery = lastURLVisited.RawQuery
+		}
+		appURL.RawPath = ""
+		return &appURL
+	}
+
+	route, err := url.Parse(strings.Replace(u.EscapedFragment(), "%23", "#", 1))
+	if err != nil {
+		return u
+	}
+	appURL.Path = rootPrefix + route.Path
+	appURL.RawPath = ""
+	appURL.RawQuery = route.RawQuery
+	appURL.Fragment = route.Fragment
+	appURL.RawFragment = ""
+	return &appURL
+}
+
+// toBrowserURL returns the URL that is displayed by the browser from the given
+// app URL. It is the reverse operation of toAppURL.
+func toBrowserURL(u *url.URL) *url.URL {
+	if !isHashRouting || u == nil {
+		return u
+	}
+
+	path := strings.TrimPrefix(u.Path, rootPrefix)
+	if path == "" {
+		path = "/"
+	}
+	route := url.URL{
+		Path:     path,
+		RawQuery: u.RawQuery,
+		Fragment: u.Fragment,
+	}
+
+	// The route fragment separator is escaped since a fragment cannot contain
+	// a "#".
+	fragment, err := url.Parse("#" + strings.Replace(route.String(), "#", "%23", 1))
+	if err != nil {
+		return u
+	}
+
+	browserURL := *u
+	browserURL.Path = rootPrefix + "/"
+	browserURL.RawPath = ""
+	browserURL.RawQuery = ""
+	browserURL.Fragment = fragment.Fragment
+	browserURL.RawFragment = fragment.RawFragment
+	return &browserURL
+}
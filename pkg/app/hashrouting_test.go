@@ -0,0 +1,103 @@
+package app
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashRoutingURLs(t *testing.T) {
+	defer func(hashRouting bool, prefix string, luv *url.URL) {
+		isHashRouting = hashRouting
+		rootPrefix = prefix
+		lastURLVisited = luv
+	}(isHashRouting, rootPrefix, lastURLVisited)
+
+	utests := []struct {
+		scenario   string
+		prefix     string
+		lastURL    string
+		browserURL string
+		appURL     string
+		reverseURL string
+	}{
+		{
+			scenario:   "root",
+			browserURL: "https://goapp.dev/",
+			appURL:     "https://goapp.dev/",
+			reverseURL: "https://goapp.dev/#/",
+		},
+		{
+			scenario:   "route",
+			browserURL: "https://goapp.dev/#/users/42",
+			appURL:     "https://goapp.dev/users/42",
+		},
+		{
+			scenario:   "route with query and fragment",
+			browserURL: "https://goapp.dev/#/users/42?tab=posts%23latest",
+			appURL:     "https://goapp.dev/users/42?tab=posts#latest",
+		},
+		{
+			scenario:   "route with escaped path",
+			browserURL: "https://goapp.dev/#/search/hello%20world",
+			appURL:     "https://goapp.dev/search/hello%20world",
+		},
+		{
+			scenario:   "route from index.html",
+			browserURL: "https://goapp.dev/index.html#/users",
+			appURL:     "https://goapp.dev/users",
+			reverseURL: "https://goapp.dev/#/users",
+		},
+		{
+			scenario:   "route with root prefix",
+			prefix:     "/go-app",
+			browserURL: "https://goapp.dev/go-app/#/users",
+			appURL:     "https://goapp.dev/go-app/users",
+		},
+		{
+			scenario:   "anchor within the current page",
+			lastURL:    "https://goapp.dev/users?tab=posts",
+			browserURL: "https://goapp.dev/#section",
+			appURL:     "https://goapp.dev/users?tab=posts#section",
+			reverseURL: "https://goapp.dev/#/users?tab=posts%23section",
+		},
+		{
+			scenario:   "path based link",
+			browserURL: "https://goapp.dev/users",
+			appURL:     "https://goapp.dev/users",
+			reverseURL: "https://goapp.dev/#/users",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			isHashRouting = true
+			rootPrefix = u.prefix
+			lastURLVisited = nil
+			if u.lastURL != "" {
+				lastURLVisited, _ = url.Parse(u.lastURL)
+			}
+
+			browserURL, err := url.Parse(u.browserURL)
+			require.NoError(t, err)
+
+			appURL := toAppURL(browserURL)
+			require.Equal(t, u.appURL, appURL.String())
+
+			reverseURL := u.reverseURL
+			if reverseURL == "" {
+				reverseURL = u.browserURL
+			}
+			require.Equal(t, reverseURL, toBrowserURL(appURL).String())
+			require.Equal(t, u.appURL, toAppURL(toBrowserURL(appURL)).String())
+		})
+	}
+}
+
+func TestHashRoutingDisabled(t *testing.T) {
+	u, err := url.Parse("https://goapp.dev/#/users")
+	require.NoError(t, err)
+	require.Equal(t, u, toAppURL(u))
+	require.Equal(t, u, toBrowserURL(u))
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+const (
+	historyEntryKey = "goappHistoryEntry"
+	historyIndexKey = "goappHistoryIndex"
+)
+
+var (
+	// The key of the history entry that is currently displayed. Keys are
+	// stored in the history entries state.
+	currentHistoryEntry string
+
+	// The position of the current history entry, relative to the first entry
+	// created by the app.
+	currentHistoryIndex int
+
+	// The number of entries the browser moved by when it last moved through
+	// the history.
+	lastHistoryMove int
+
+	// The scroll positions of the visited history entries, by key.
+	scrollPositions = make(map[string]scrollPosition)
+)
+
+type scrollPosition struct {
+	X float64
+	Y float64
+}
+
+// initHistory makes the app responsible for restoring the scroll position when
+// moving through the history, and identifies the current history entry.
+func initHistory() {
+	Window().Get("history").Set("scrollRestoration", "manual")
+	enterHistoryEntry(Window().Get("history").Get("state"))
+}
+
+// newHistoryEntry saves the scroll position of the current history entry and
+// returns the state of a new one.
+func newHistoryEntry() map[string]any {
+	saveScrollPosition()
+	currentHistoryEntry = uuid.NewString()
+	currentHistoryIndex++
+	return historyEntryState()
+}
+
+// enterHistoryEntry saves the scroll position of the current history entry and
+// makes the entry with the given state the current one. It is called when the
+// browser moves through the history.
+func enterHistoryEntry(state Value) {
+	saveScrollPosition()
+	previousIndex := currentHistoryIndex
+
+	if state.Truthy() {
+		if key := state.Get(historyEntryKey); key.Truthy() {
+			currentHistoryEntry = key.String()
+			currentHistoryIndex = state.Get(historyIndexKey).Int()
+			lastHistoryMove = currentHistoryIndex - previousIndex
+			return
+		}
+	}
+
+	currentHistoryEntry = uuid.NewString()
+	lastHistoryMove = 0
+	Window().Get("history").Call("replaceState", historyEntryState(), "", Window().URL().String())
+}
+
+// cancelHistoryMove moves the browser back to the history entry it was
+// displaying before it last moved through the history. The URL is replaced
+// when the move is unknown.
+func cancelHistoryMove(previous *url.URL) {
+	if lastHistoryMove == 0 {
+		Window().replaceHistory(previous)
+		return
+	}
+
+	Window().Get("history").Call("go", -lastHistoryMove)
+	lastHistoryMove = 0
+}
+
+func historyEntryState() map[string]any {
+	return map[string]any{
+		historyEntryKey: currentHistoryEntry,
+		historyIndexKey: currentHistoryIndex,
+	}
+}
+
+func saveScrollPosition() {
+	if currentHistoryEntry == "" {
+		return
+	}
+
+	scrollPositions[currentHistoryEntry] = scrollPosition{
+		X: Window().Get("scrollX").Float(),
+		Y: Window().Get("scrollY").Float(),
+	}
+}
+
+// restoreScrollPosition scrolls to the element targeted by the fragment of the
+// given URL, or to the position saved for the current history entry. New
+// history entries are scrolled to the top.
+func restoreScrollPosition(u *url.URL, isNewEntry bool) {
+	if isFragmentNavigation(u) {
+		Window().ScrollToID(u.Fragment)
+		return
+	}
+
+	if pos, ok := scrollPositions[currentHistoryEntry]; ok {
+		Window().Call("scrollTo", pos.X, pos.Y)
+		return
+	}
+
+	if isNewEntry {
+		Window().Call("scrollTo", 0, 0)
+	}
+}
@@ -13,6 +13,7 @@ type htmlElement struct {
 	tag           string
 	xmlns         string
 	isSelfClosing bool
+	key           any
 	attributes    attributes
 	eventHandlers eventHandlers
 	parent        UI
@@ -65,6 +66,15 @@ func (e *htmlElement) getEventHandlers() eventHandlers {
 	return e.eventHandlers
 }
 
+func (e *htmlElement) getKey() any {
+	return e.key
+}
+
+func (e *htmlElement) setKey(v any) {
+	checkKey(v)
+	e.key = v
+}
+
 func (e *htmlElement) getParent() UI {
 	return e.parent
 }
@@ -84,7 +94,7 @@ func (e *htmlElement) mount(d Dispatcher) error {
 			WithTag("kind", e.Kind())
 	}
 
-	e.context, e.contextCancel = context.WithCancel(context.Background())
+	e.context, e.contextCancel = context.WithCancel(d.getBaseContext())
 	e.dispatcher = d
 
 	jsElement, err := Window().createElement(e.tag, e.xmlns)
@@ -97,7 +107,7 @@ func (e *htmlElement) mount(d Dispatcher) error {
 	}
 	e.jsElement = jsElement
 
-	e.attributes.Mount(jsElement, d.resolveStaticResource)
+	e.attributes.Mount(jsElement, d.resolveStaticResource, d.getBasePath())
 	e.eventHandlers.Mount(e)
 
 	for i, c := range e.children {
@@ -110,13 +120,55 @@ func (e *htmlElement) mount(d Dispatcher) error {
 		}
 
 		c.setParent(e.self())
-		e.JSValue().appendChild(c)
+		insertJSNodes(e.JSValue(), c, nil)
 	}
 
 	return nil
 }
 
+func (e *htmlElement) hydrate(d Dispatcher, c *hydrationCursor) error {
+	if e.Mounted() {
+		return e.mount(d)
+	}
+
+	jsElement, ok := c.next()
+	if !ok || !isMatchingJSElement(jsElement, e.tag) {
+		return e.mount(d)
+	}
+	c.adopt()
+
+	e.context, e.contextCancel = context.WithCancel(d.getBaseContext())
+	e.dispatcher = d
+	e.jsElement = jsElement
+
+	names := jsElement.Call("getAttributeNames")
+	for i, l := 0, names.Length(); i < l; i++ {
+		if name := names.Index(i).String(); !e.hasAttribute(name) {
+			deleteJSAttribute(jsElement, name)
+		}
+	}
+	e.attributes.Mount(jsElement, d.resolveStaticResource, d.getBasePath())
+	e.eventHandlers.Mount(e)
+
+	if err := hydrateChildren(d, e.self(), jsElement, e.children); err != nil {
+		return errors.New("hydrating html element failed").
+			WithTag("tag", e.tag).
+			WithTag("kind", e.Kind()).
+			Wrap(err)
+	}
+	return nil
+}
+
+func (e *htmlElement) hasAttribute(name string) bool {
+	_, ok := e.attributes[name]
+	return ok
+}
+
 func (e *htmlElement) dismount() {
+	if e.contextCancel == nil {
+		return
+	}
+
 	for _, c := range e.children {
 		dismount(c)
 	}
@@ -131,7 +183,8 @@ func (e *htmlElement) dismount() {
 func (e *htmlElement) canUpdateWith(v UI) bool {
 	return e.Mounted() &&
 		e.Kind() == v.Kind() &&
-		e.name() == v.name()
+		e.name() == v.name() &&
+		e.getKey() == v.getKey()
 }
 
 func (e *htmlElement) updateWith(v UI) error {
@@ -143,12 +196,13 @@ func (e *htmlElement) updateWith(v UI) error {
 
 	if e.attributes == nil && v.getAttributes() != nil {
 		e.attributes = v.getAttributes()
-		e.attributes.Mount(e.jsElement, e.dispatcher.resolveStaticResource)
+		e.attributes.Mount(e.jsElement, e.dispatcher.resolveStaticResource, e.dispatcher.getBasePath())
 	} else if e.attributes != nil {
 		e.attributes.Update(
 			e.jsElement,
 			v.getAttributes(),
 			e.getDispatcher().resolveStaticResource,
+			e.getDispatcher().getBasePath(),
 		)
 	}
 
@@ -159,62 +213,9 @@ func (e *htmlElement) updateWith(v UI) error {
 		e.eventHandlers.Update(e, v.getEventHandlers())
 	}
 
-	childrenA := e.children
-	childrenB := v.getChildren()
-	i := 0
-
-	for len(childrenA) != 0 && len(childrenB) != 0 {
-		a := childrenA[0]
-		b := childrenB[0]
-
-		if canUpdate(a, b) {
-			if err := update(a, b); err != nil {
-				return errors.New("updating child failed").
-					WithTag("child", reflect.TypeOf(a)).
-					WithTag("new-child", reflect.TypeOf(b)).
-					WithTag("index", i).
-					Wrap(err)
-			}
-		} else {
-			if err := e.replaceChildAt(i, b); err != nil {
-				return errors.New("replacing child failed").
-					WithTag("child", reflect.TypeOf(a)).
-					WithTag("new-child", reflect.TypeOf(b)).
-					WithTag("index", i).
-					Wrap(err)
-			}
-		}
-
-		childrenA = childrenA[1:]
-		childrenB = childrenB[1:]
-		i++
-	}
-
-	for len(childrenA) != 0 {
-		if err := e.removeChildAt(i); err != nil {
-			return errors.New("removing child failed").
-				WithTag("child", reflect.TypeOf(childrenA[0])).
-				WithTag("index", i).
-				Wrap(err)
-		}
-
-		childrenA = childrenA[1:]
-	}
-
-	for len(childrenB) != 0 {
-		b := childrenB[0]
-
-		if err := e.appendChild(b); err != nil {
-			return errors.New("appending child failed").
-				WithTag("child", reflect.TypeOf(b)).
-				WithTag("index", i).
-				Wrap(err)
-		}
-
-		childrenB = childrenB[1:]
-	}
-
-	return nil
+	children, err := updateChildren(e.self(), e.JSValue(), nil, e.children, v.getChildren())
+	e.children = children
+	return err
 }
 
 func (e *htmlElement) replaceChildAt(idx int, new UI) error {
@@ -234,40 +235,31 @@ func (e *htmlElement) replaceChildAt(idx int, new UI) error {
 
 	e.children[idx] = new
 	new.setParent(e.self())
-	e.JSValue().replaceChild(new, old)
+	replaceJSNodes(e.JSValue(), new, old)
 
 	dismount(old)
 	return nil
 }
 
-func (e *htmlElement) removeChildAt(i int) error {
-	if i < 0 || i >= len(e.children) {
-		return errors.New("index out of range").
-			WithTag("index", i).
-			WithTag("children-count", len(e.children))
-	}
-
-	child := e.children[i]
-	e.jsElement.removeChild(child)
-	dismount(child)
-
-	children := e.children
-	copy(children[i:], children[i+1:])
-	children[len(children)-1] = nil
-	e.children = children[:len(children)-1]
-	return nil
-}
+func (e *htmlElement) hydrateChildAt(idx int, new UI, keep func(Value) bool) error {
+	old := e.children[idx]
 
-func (e *htmlElement) appendChild(v UI) error {
-	if err := mount(e.getDispatcher(), v); err != nil {
-		return errors.New("mounting element failed").
-			WithTag("element", reflect.TypeOf(v)).
+	cursor := newHydrationCursor(e.JSValue(), keep)
+	if err := hydrate(e.getDispatcher(), new, cursor); err != nil {
+		return errors.New("hydrating child failed").
+			WithTag("name", e.name()).
+			WithTag("kind", e.Kind()).
+			WithTag("index", idx).
+			WithTag("new-name", new.name()).
+			WithTag("new-kind", new.Kind()).
 			Wrap(err)
 	}
 
-	v.setParent(e.self())
-	e.JSValue().appendChild(v)
-	e.children = append(e.children, v)
+	e.children[idx] = new
+	new.setParent(e.self())
+	cursor.finish(e.JSValue(), e.children)
+
+	dismount(old)
 	return nil
 }
 
@@ -315,7 +307,8 @@ func (e *htmlElement) html(w io.Writer) {
 		return
 	}
 
-	hasNewLineChildren := len(e.children) > 1
+	hasNewLineChildren := len(e.children) > 1 ||
+		(len(e.children) == 1 && e.children[0].Kind() == Fragment)
 
 	for _, c := range e.children {
 		if hasNewLineChildren {
@@ -377,7 +370,7 @@ func (e *htmlElement) htmlWithIndent(w io.Writer, indent int) {
 }
 
 func (e *htmlElement) writeHTMLAttribute(w io.Writer, k, v string) {
-	if (k == "id" || k == "class") && v == "" {
+	if (k == "id" || k == "class" || k == "nonce" || k == "integrity") && v == "" {
 		return
 	}
 
@@ -386,11 +379,12 @@ func (e *htmlElement) writeHTMLAttribute(w io.Writer, k, v string) {
 
 	if v != "" && v != "true" {
 		io.WriteString(w, `=`)
-		io.WriteString(w, strconv.Quote(resolveAttributeURLValue(k, v, func(s string) string {
-			if e.dispatcher != nil {
-				return e.dispatcher.resolveStaticResource(s)
-			}
-			return s
-		})))
+		resolveURL := func(s string) string { return s }
+		basePath := ""
+		if e.dispatcher != nil {
+			resolveURL = e.dispatcher.resolveStaticResource
+			basePath = e.dispatcher.getBasePath()
+		}
+		io.WriteString(w, strconv.Quote(resolveAttributeURLValue(k, v, resolveURL, basePath)))
 	}
 }
@@ -19,6 +19,9 @@ type HTMLA interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLA
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLA
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLA
 
@@ -232,6 +235,11 @@ func (e *htmlA) Textf(format string, v ...any) HTMLA {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlA) Key(v any) HTMLA {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlA) AccessKey(format string, v ...any) HTMLA {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -559,6 +567,9 @@ type HTMLAbbr interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLAbbr
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLAbbr
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLAbbr
 
@@ -748,6 +759,11 @@ func (e *htmlAbbr) Textf(format string, v ...any) HTMLAbbr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAbbr) Key(v any) HTMLAbbr {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlAbbr) AccessKey(format string, v ...any) HTMLAbbr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -1035,6 +1051,9 @@ type HTMLAddress interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLAddress
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLAddress
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLAddress
 
@@ -1224,6 +1243,11 @@ func (e *htmlAddress) Textf(format string, v ...any) HTMLAddress {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAddress) Key(v any) HTMLAddress {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlAddress) AccessKey(format string, v ...any) HTMLAddress {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -1502,6 +1526,9 @@ func (e *htmlAddress) OnWheel(h EventHandler, scope ...any) HTMLAddress {
 type HTMLArea interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLArea
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLArea
 
@@ -1708,6 +1735,11 @@ type htmlArea struct {
 	htmlElement
 }
 
+func (e *htmlArea) Key(v any) HTMLArea {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlArea) AccessKey(format string, v ...any) HTMLArea {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2045,6 +2077,9 @@ type HTMLArticle interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLArticle
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLArticle
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLArticle
 
@@ -2234,6 +2269,11 @@ func (e *htmlArticle) Textf(format string, v ...any) HTMLArticle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlArticle) Key(v any) HTMLArticle {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlArticle) AccessKey(format string, v ...any) HTMLArticle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2521,6 +2561,9 @@ type HTMLAside interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLAside
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLAside
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLAside
 
@@ -2710,6 +2753,11 @@ func (e *htmlAside) Textf(format string, v ...any) HTMLAside {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAside) Key(v any) HTMLAside {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlAside) AccessKey(format string, v ...any) HTMLAside {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -2997,6 +3045,9 @@ type HTMLAudio interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLAudio
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLAudio
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLAudio
 
@@ -3276,6 +3327,11 @@ func (e *htmlAudio) Textf(format string, v ...any) HTMLAudio {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlAudio) Key(v any) HTMLAudio {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlAudio) AccessKey(format string, v ...any) HTMLAudio {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -3713,6 +3769,9 @@ type HTMLB interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLB
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLB
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLB
 
@@ -3902,6 +3961,11 @@ func (e *htmlB) Textf(format string, v ...any) HTMLB {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlB) Key(v any) HTMLB {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlB) AccessKey(format string, v ...any) HTMLB {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -4180,6 +4244,9 @@ func (e *htmlB) OnWheel(h EventHandler, scope ...any) HTMLB {
 type HTMLBase interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBase
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBase
 
@@ -4362,6 +4429,11 @@ type htmlBase struct {
 	htmlElement
 }
 
+func (e *htmlBase) Key(v any) HTMLBase {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBase) AccessKey(format string, v ...any) HTMLBase {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -4659,6 +4731,9 @@ type HTMLBdi interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLBdi
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBdi
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBdi
 
@@ -4848,6 +4923,11 @@ func (e *htmlBdi) Textf(format string, v ...any) HTMLBdi {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBdi) Key(v any) HTMLBdi {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBdi) AccessKey(format string, v ...any) HTMLBdi {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -5135,6 +5215,9 @@ type HTMLBdo interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLBdo
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBdo
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBdo
 
@@ -5324,6 +5407,11 @@ func (e *htmlBdo) Textf(format string, v ...any) HTMLBdo {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBdo) Key(v any) HTMLBdo {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBdo) AccessKey(format string, v ...any) HTMLBdo {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -5611,6 +5699,9 @@ type HTMLBlockquote interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLBlockquote
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBlockquote
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBlockquote
 
@@ -5803,6 +5894,11 @@ func (e *htmlBlockquote) Textf(format string, v ...any) HTMLBlockquote {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlBlockquote) Key(v any) HTMLBlockquote {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBlockquote) AccessKey(format string, v ...any) HTMLBlockquote {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -6088,6 +6184,9 @@ type HTMLBody interface {
 
 	privateBody(elems ...UI) HTMLBody
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBody
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBody
 
@@ -6314,6 +6413,11 @@ func (e *htmlBody) privateBody(v ...UI) HTMLBody {
 	return e
 }
 
+func (e *htmlBody) Key(v any) HTMLBody {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBody) AccessKey(format string, v ...any) HTMLBody {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -6667,6 +6771,9 @@ func (e *htmlBody) OnWheel(h EventHandler, scope ...any) HTMLBody {
 type HTMLBr interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLBr
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLBr
 
@@ -6843,6 +6950,11 @@ type htmlBr struct {
 	htmlElement
 }
 
+func (e *htmlBr) Key(v any) HTMLBr {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlBr) AccessKey(format string, v ...any) HTMLBr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -7130,6 +7242,9 @@ type HTMLButton interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLButton
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLButton
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLButton
 
@@ -7352,6 +7467,11 @@ func (e *htmlButton) Textf(format string, v ...any) HTMLButton {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlButton) Key(v any) HTMLButton {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlButton) AccessKey(format string, v ...any) HTMLButton {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -7694,6 +7814,9 @@ type HTMLCanvas interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLCanvas
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLCanvas
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLCanvas
 
@@ -7889,6 +8012,11 @@ func (e *htmlCanvas) Textf(format string, v ...any) HTMLCanvas {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCanvas) Key(v any) HTMLCanvas {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlCanvas) AccessKey(format string, v ...any) HTMLCanvas {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -8186,6 +8314,9 @@ type HTMLCaption interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLCaption
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLCaption
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLCaption
 
@@ -8375,6 +8506,11 @@ func (e *htmlCaption) Textf(format string, v ...any) HTMLCaption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCaption) Key(v any) HTMLCaption {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlCaption) AccessKey(format string, v ...any) HTMLCaption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -8662,6 +8798,9 @@ type HTMLCite interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLCite
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLCite
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLCite
 
@@ -8851,6 +8990,11 @@ func (e *htmlCite) Textf(format string, v ...any) HTMLCite {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCite) Key(v any) HTMLCite {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlCite) AccessKey(format string, v ...any) HTMLCite {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -9138,6 +9282,9 @@ type HTMLCode interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLCode
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLCode
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLCode
 
@@ -9327,6 +9474,11 @@ func (e *htmlCode) Textf(format string, v ...any) HTMLCode {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlCode) Key(v any) HTMLCode {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlCode) AccessKey(format string, v ...any) HTMLCode {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -9605,6 +9757,9 @@ func (e *htmlCode) OnWheel(h EventHandler, scope ...any) HTMLCode {
 type HTMLCol interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLCol
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLCol
 
@@ -9784,6 +9939,11 @@ type htmlCol struct {
 	htmlElement
 }
 
+func (e *htmlCol) Key(v any) HTMLCol {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlCol) AccessKey(format string, v ...any) HTMLCol {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -10076,6 +10236,9 @@ type HTMLColGroup interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLColGroup
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLColGroup
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLColGroup
 
@@ -10268,6 +10431,11 @@ func (e *htmlColGroup) Textf(format string, v ...any) HTMLColGroup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlColGroup) Key(v any) HTMLColGroup {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlColGroup) AccessKey(format string, v ...any) HTMLColGroup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -10560,6 +10728,9 @@ type HTMLData interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLData
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLData
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLData
 
@@ -10650,6 +10821,11 @@ func (e *htmlData) Textf(format string, v ...any) HTMLData {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlData) Key(v any) HTMLData {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlData) AccessKey(format string, v ...any) HTMLData {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -10772,6 +10948,9 @@ type HTMLDataList interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDataList
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDataList
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDataList
 
@@ -10961,6 +11140,11 @@ func (e *htmlDataList) Textf(format string, v ...any) HTMLDataList {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDataList) Key(v any) HTMLDataList {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDataList) AccessKey(format string, v ...any) HTMLDataList {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -11248,6 +11432,9 @@ type HTMLDd interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDd
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDd
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDd
 
@@ -11437,6 +11624,11 @@ func (e *htmlDd) Textf(format string, v ...any) HTMLDd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDd) Key(v any) HTMLDd {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDd) AccessKey(format string, v ...any) HTMLDd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -11724,6 +11916,9 @@ type HTMLDel interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDel
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDel
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDel
 
@@ -11919,6 +12114,11 @@ func (e *htmlDel) Textf(format string, v ...any) HTMLDel {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDel) Key(v any) HTMLDel {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDel) AccessKey(format string, v ...any) HTMLDel {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -12216,6 +12416,9 @@ type HTMLDetails interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDetails
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDetails
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDetails
 
@@ -12411,6 +12614,11 @@ func (e *htmlDetails) Textf(format string, v ...any) HTMLDetails {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDetails) Key(v any) HTMLDetails {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDetails) AccessKey(format string, v ...any) HTMLDetails {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -12708,6 +12916,9 @@ type HTMLDfn interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDfn
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDfn
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDfn
 
@@ -12897,6 +13108,11 @@ func (e *htmlDfn) Textf(format string, v ...any) HTMLDfn {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDfn) Key(v any) HTMLDfn {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDfn) AccessKey(format string, v ...any) HTMLDfn {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -13184,6 +13400,9 @@ type HTMLDialog interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDialog
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDialog
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDialog
 
@@ -13376,6 +13595,11 @@ func (e *htmlDialog) Textf(format string, v ...any) HTMLDialog {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDialog) Key(v any) HTMLDialog {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDialog) AccessKey(format string, v ...any) HTMLDialog {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -13668,6 +13892,9 @@ type HTMLDiv interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDiv
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDiv
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDiv
 
@@ -13857,6 +14084,11 @@ func (e *htmlDiv) Textf(format string, v ...any) HTMLDiv {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDiv) Key(v any) HTMLDiv {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDiv) AccessKey(format string, v ...any) HTMLDiv {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -14144,6 +14376,9 @@ type HTMLDl interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDl
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDl
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDl
 
@@ -14333,6 +14568,11 @@ func (e *htmlDl) Textf(format string, v ...any) HTMLDl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDl) Key(v any) HTMLDl {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDl) AccessKey(format string, v ...any) HTMLDl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -14620,6 +14860,9 @@ type HTMLDt interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLDt
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLDt
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLDt
 
@@ -14809,6 +15052,11 @@ func (e *htmlDt) Textf(format string, v ...any) HTMLDt {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlDt) Key(v any) HTMLDt {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlDt) AccessKey(format string, v ...any) HTMLDt {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -15096,6 +15344,9 @@ type HTMLElem interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLElem
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLElem
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLElem
 
@@ -15288,6 +15539,11 @@ func (e *htmlElem) Textf(format string, v ...any) HTMLElem {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlElem) Key(v any) HTMLElem {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlElem) AccessKey(format string, v ...any) HTMLElem {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -15571,6 +15827,9 @@ func (e *htmlElem) OnWheel(h EventHandler, scope ...any) HTMLElem {
 type HTMLElemSelfClosing interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLElemSelfClosing
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLElemSelfClosing
 
@@ -15750,6 +16009,11 @@ type htmlElemSelfClosing struct {
 	htmlElement
 }
 
+func (e *htmlElemSelfClosing) Key(v any) HTMLElemSelfClosing {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlElemSelfClosing) AccessKey(format string, v ...any) HTMLElemSelfClosing {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -16042,6 +16306,9 @@ type HTMLEm interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLEm
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLEm
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLEm
 
@@ -16231,6 +16498,11 @@ func (e *htmlEm) Textf(format string, v ...any) HTMLEm {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlEm) Key(v any) HTMLEm {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlEm) AccessKey(format string, v ...any) HTMLEm {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -16509,6 +16781,9 @@ func (e *htmlEm) OnWheel(h EventHandler, scope ...any) HTMLEm {
 type HTMLEmbed interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLEmbed
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLEmbed
 
@@ -16766,6 +17041,11 @@ type htmlEmbed struct {
 	htmlElement
 }
 
+func (e *htmlEmbed) Key(v any) HTMLEmbed {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlEmbed) AccessKey(format string, v ...any) HTMLEmbed {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -17188,6 +17468,9 @@ type HTMLFieldSet interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLFieldSet
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLFieldSet
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLFieldSet
 
@@ -17386,6 +17669,11 @@ func (e *htmlFieldSet) Textf(format string, v ...any) HTMLFieldSet {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFieldSet) Key(v any) HTMLFieldSet {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlFieldSet) AccessKey(format string, v ...any) HTMLFieldSet {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -17688,6 +17976,9 @@ type HTMLFigCaption interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLFigCaption
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLFigCaption
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLFigCaption
 
@@ -17877,6 +18168,11 @@ func (e *htmlFigCaption) Textf(format string, v ...any) HTMLFigCaption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFigCaption) Key(v any) HTMLFigCaption {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlFigCaption) AccessKey(format string, v ...any) HTMLFigCaption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -18164,6 +18460,9 @@ type HTMLFigure interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLFigure
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLFigure
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLFigure
 
@@ -18353,6 +18652,11 @@ func (e *htmlFigure) Textf(format string, v ...any) HTMLFigure {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFigure) Key(v any) HTMLFigure {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlFigure) AccessKey(format string, v ...any) HTMLFigure {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -18640,6 +18944,9 @@ type HTMLFooter interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLFooter
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLFooter
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLFooter
 
@@ -18829,6 +19136,11 @@ func (e *htmlFooter) Textf(format string, v ...any) HTMLFooter {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlFooter) Key(v any) HTMLFooter {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlFooter) AccessKey(format string, v ...any) HTMLFooter {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -19116,6 +19428,9 @@ type HTMLForm interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLForm
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLForm
+
 	// AcceptCharset specifies the character encodings that are to be used for the form submission.
 	AcceptCharset(v string) HTMLForm
 
@@ -19329,6 +19644,11 @@ func (e *htmlForm) Textf(format string, v ...any) HTMLForm {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlForm) Key(v any) HTMLForm {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlForm) AcceptCharset(v string) HTMLForm {
 	e.setAttr("accept-charset", v)
 	return e
@@ -19661,6 +19981,9 @@ type HTMLH1 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH1
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH1
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH1
 
@@ -19850,6 +20173,11 @@ func (e *htmlH1) Textf(format string, v ...any) HTMLH1 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH1) Key(v any) HTMLH1 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH1) AccessKey(format string, v ...any) HTMLH1 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -20137,6 +20465,9 @@ type HTMLH2 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH2
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH2
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH2
 
@@ -20326,6 +20657,11 @@ func (e *htmlH2) Textf(format string, v ...any) HTMLH2 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH2) Key(v any) HTMLH2 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH2) AccessKey(format string, v ...any) HTMLH2 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -20613,6 +20949,9 @@ type HTMLH3 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH3
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH3
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH3
 
@@ -20802,6 +21141,11 @@ func (e *htmlH3) Textf(format string, v ...any) HTMLH3 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH3) Key(v any) HTMLH3 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH3) AccessKey(format string, v ...any) HTMLH3 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -21089,6 +21433,9 @@ type HTMLH4 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH4
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH4
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH4
 
@@ -21278,6 +21625,11 @@ func (e *htmlH4) Textf(format string, v ...any) HTMLH4 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH4) Key(v any) HTMLH4 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH4) AccessKey(format string, v ...any) HTMLH4 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -21565,6 +21917,9 @@ type HTMLH5 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH5
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH5
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH5
 
@@ -21754,6 +22109,11 @@ func (e *htmlH5) Textf(format string, v ...any) HTMLH5 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH5) Key(v any) HTMLH5 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH5) AccessKey(format string, v ...any) HTMLH5 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22041,6 +22401,9 @@ type HTMLH6 interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLH6
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLH6
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLH6
 
@@ -22230,6 +22593,11 @@ func (e *htmlH6) Textf(format string, v ...any) HTMLH6 {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlH6) Key(v any) HTMLH6 {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlH6) AccessKey(format string, v ...any) HTMLH6 {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22517,6 +22885,9 @@ type HTMLHead interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLHead
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLHead
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLHead
 
@@ -22604,6 +22975,11 @@ func (e *htmlHead) Textf(format string, v ...any) HTMLHead {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlHead) Key(v any) HTMLHead {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlHead) AccessKey(format string, v ...any) HTMLHead {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -22721,6 +23097,9 @@ type HTMLHeader interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLHeader
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLHeader
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLHeader
 
@@ -22910,6 +23289,11 @@ func (e *htmlHeader) Textf(format string, v ...any) HTMLHeader {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlHeader) Key(v any) HTMLHeader {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlHeader) AccessKey(format string, v ...any) HTMLHeader {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -23188,6 +23572,9 @@ func (e *htmlHeader) OnWheel(h EventHandler, scope ...any) HTMLHeader {
 type HTMLHr interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLHr
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLHr
 
@@ -23364,6 +23751,11 @@ type htmlHr struct {
 	htmlElement
 }
 
+func (e *htmlHr) Key(v any) HTMLHr {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlHr) AccessKey(format string, v ...any) HTMLHr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -23644,6 +24036,9 @@ type HTMLHtml interface {
 
 	privateBody(elems ...UI) HTMLHtml
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLHtml
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLHtml
 
@@ -23723,6 +24118,11 @@ func (e *htmlHtml) privateBody(v ...UI) HTMLHtml {
 	return e
 }
 
+func (e *htmlHtml) Key(v any) HTMLHtml {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlHtml) AccessKey(format string, v ...any) HTMLHtml {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -23840,6 +24240,9 @@ type HTMLI interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLI
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLI
 
@@ -24029,6 +24432,11 @@ func (e *htmlI) Textf(format string, v ...any) HTMLI {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlI) Key(v any) HTMLI {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlI) AccessKey(format string, v ...any) HTMLI {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -24316,6 +24724,9 @@ type HTMLIFrame interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLIFrame
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLIFrame
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLIFrame
 
@@ -24541,6 +24952,11 @@ func (e *htmlIFrame) Textf(format string, v ...any) HTMLIFrame {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlIFrame) Key(v any) HTMLIFrame {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlIFrame) AccessKey(format string, v ...any) HTMLIFrame {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -24889,6 +25305,9 @@ func (e *htmlIFrame) OnWheel(h EventHandler, scope ...any) HTMLIFrame {
 type HTMLImg interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLImg
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLImg
 
@@ -25167,6 +25586,11 @@ type htmlImg struct {
 	htmlElement
 }
 
+func (e *htmlImg) Key(v any) HTMLImg {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlImg) AccessKey(format string, v ...any) HTMLImg {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -25615,6 +26039,9 @@ func (e *htmlImg) OnWheel(h EventHandler, scope ...any) HTMLImg {
 type HTMLInput interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLInput
+
 	// Accept specifies the types of files that the server accepts (only for file type) with the given format and values.
 	Accept(format string, v ...any) HTMLInput
 
@@ -25887,6 +26314,11 @@ type htmlInput struct {
 	htmlElement
 }
 
+func (e *htmlInput) Key(v any) HTMLInput {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlInput) Accept(format string, v ...any) HTMLInput {
 	e.setAttr("accept", FormatString(format, v...))
 	return e
@@ -26339,6 +26771,9 @@ type HTMLIns interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLIns
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLIns
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLIns
 
@@ -26528,6 +26963,11 @@ func (e *htmlIns) Textf(format string, v ...any) HTMLIns {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlIns) Key(v any) HTMLIns {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlIns) AccessKey(format string, v ...any) HTMLIns {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -26815,6 +27255,9 @@ type HTMLKbd interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLKbd
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLKbd
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLKbd
 
@@ -27004,6 +27447,11 @@ func (e *htmlKbd) Textf(format string, v ...any) HTMLKbd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlKbd) Key(v any) HTMLKbd {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlKbd) AccessKey(format string, v ...any) HTMLKbd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -27291,6 +27739,9 @@ type HTMLLabel interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLLabel
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLLabel
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLLabel
 
@@ -27486,6 +27937,11 @@ func (e *htmlLabel) Textf(format string, v ...any) HTMLLabel {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLabel) Key(v any) HTMLLabel {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlLabel) AccessKey(format string, v ...any) HTMLLabel {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -27783,6 +28239,9 @@ type HTMLLegend interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLLegend
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLLegend
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLLegend
 
@@ -27972,6 +28431,11 @@ func (e *htmlLegend) Textf(format string, v ...any) HTMLLegend {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLegend) Key(v any) HTMLLegend {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlLegend) AccessKey(format string, v ...any) HTMLLegend {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -28259,6 +28723,9 @@ type HTMLLi interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLLi
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLLi
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLLi
 
@@ -28451,6 +28918,11 @@ func (e *htmlLi) Textf(format string, v ...any) HTMLLi {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlLi) Key(v any) HTMLLi {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlLi) AccessKey(format string, v ...any) HTMLLi {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -28734,6 +29206,9 @@ func (e *htmlLi) OnWheel(h EventHandler, scope ...any) HTMLLi {
 type HTMLLink interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLLink
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLLink
 
@@ -28940,6 +29415,11 @@ type htmlLink struct {
 	htmlElement
 }
 
+func (e *htmlLink) Key(v any) HTMLLink {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlLink) AccessKey(format string, v ...any) HTMLLink {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -29277,6 +29757,9 @@ type HTMLMain interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLMain
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLMain
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLMain
 
@@ -29466,6 +29949,11 @@ func (e *htmlMain) Textf(format string, v ...any) HTMLMain {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMain) Key(v any) HTMLMain {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlMain) AccessKey(format string, v ...any) HTMLMain {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -29753,6 +30241,9 @@ type HTMLMap interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLMap
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLMap
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLMap
 
@@ -29945,6 +30436,11 @@ func (e *htmlMap) Textf(format string, v ...any) HTMLMap {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMap) Key(v any) HTMLMap {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlMap) AccessKey(format string, v ...any) HTMLMap {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -30237,6 +30733,9 @@ type HTMLMark interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLMark
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLMark
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLMark
 
@@ -30426,6 +30925,11 @@ func (e *htmlMark) Textf(format string, v ...any) HTMLMark {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMark) Key(v any) HTMLMark {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlMark) AccessKey(format string, v ...any) HTMLMark {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -30704,6 +31208,9 @@ func (e *htmlMark) OnWheel(h EventHandler, scope ...any) HTMLMark {
 type HTMLMeta interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLMeta
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLMeta
 
@@ -30793,6 +31300,11 @@ type htmlMeta struct {
 	htmlElement
 }
 
+func (e *htmlMeta) Key(v any) HTMLMeta {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlMeta) AccessKey(format string, v ...any) HTMLMeta {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -30935,6 +31447,9 @@ type HTMLMeter interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLMeter
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLMeter
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLMeter
 
@@ -31145,6 +31660,11 @@ func (e *htmlMeter) Textf(format string, v ...any) HTMLMeter {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlMeter) Key(v any) HTMLMeter {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlMeter) AccessKey(format string, v ...any) HTMLMeter {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -31467,6 +31987,9 @@ type HTMLNav interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLNav
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLNav
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLNav
 
@@ -31656,6 +32179,11 @@ func (e *htmlNav) Textf(format string, v ...any) HTMLNav {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlNav) Key(v any) HTMLNav {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlNav) AccessKey(format string, v ...any) HTMLNav {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -31943,6 +32471,9 @@ type HTMLNoScript interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLNoScript
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLNoScript
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLNoScript
 
@@ -32030,6 +32561,11 @@ func (e *htmlNoScript) Textf(format string, v ...any) HTMLNoScript {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlNoScript) Key(v any) HTMLNoScript {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlNoScript) AccessKey(format string, v ...any) HTMLNoScript {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -32147,6 +32683,9 @@ type HTMLObject interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLObject
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLObject
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLObject
 
@@ -32426,6 +32965,11 @@ func (e *htmlObject) Textf(format string, v ...any) HTMLObject {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlObject) Key(v any) HTMLObject {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlObject) AccessKey(format string, v ...any) HTMLObject {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -32863,6 +33407,9 @@ type HTMLOl interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLOl
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLOl
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLOl
 
@@ -33061,6 +33608,11 @@ func (e *htmlOl) Textf(format string, v ...any) HTMLOl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOl) Key(v any) HTMLOl {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlOl) AccessKey(format string, v ...any) HTMLOl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -33363,6 +33915,9 @@ type HTMLOptGroup interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLOptGroup
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLOptGroup
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLOptGroup
 
@@ -33558,6 +34113,11 @@ func (e *htmlOptGroup) Textf(format string, v ...any) HTMLOptGroup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOptGroup) Key(v any) HTMLOptGroup {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlOptGroup) AccessKey(format string, v ...any) HTMLOptGroup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -33855,6 +34415,9 @@ type HTMLOption interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLOption
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLOption
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLOption
 
@@ -34056,6 +34619,11 @@ func (e *htmlOption) Textf(format string, v ...any) HTMLOption {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOption) Key(v any) HTMLOption {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlOption) AccessKey(format string, v ...any) HTMLOption {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -34363,6 +34931,9 @@ type HTMLOutput interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLOutput
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLOutput
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLOutput
 
@@ -34561,6 +35132,11 @@ func (e *htmlOutput) Textf(format string, v ...any) HTMLOutput {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlOutput) Key(v any) HTMLOutput {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlOutput) AccessKey(format string, v ...any) HTMLOutput {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -34863,6 +35439,9 @@ type HTMLP interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLP
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLP
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLP
 
@@ -35052,6 +35631,11 @@ func (e *htmlP) Textf(format string, v ...any) HTMLP {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlP) Key(v any) HTMLP {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlP) AccessKey(format string, v ...any) HTMLP {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -35330,6 +35914,9 @@ func (e *htmlP) OnWheel(h EventHandler, scope ...any) HTMLP {
 type HTMLParam interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLParam
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLParam
 
@@ -35512,6 +36099,11 @@ type htmlParam struct {
 	htmlElement
 }
 
+func (e *htmlParam) Key(v any) HTMLParam {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlParam) AccessKey(format string, v ...any) HTMLParam {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -35809,6 +36401,9 @@ type HTMLPicture interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLPicture
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLPicture
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLPicture
 
@@ -35998,6 +36593,11 @@ func (e *htmlPicture) Textf(format string, v ...any) HTMLPicture {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlPicture) Key(v any) HTMLPicture {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlPicture) AccessKey(format string, v ...any) HTMLPicture {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -36285,6 +36885,9 @@ type HTMLPre interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLPre
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLPre
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLPre
 
@@ -36474,6 +37077,11 @@ func (e *htmlPre) Textf(format string, v ...any) HTMLPre {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlPre) Key(v any) HTMLPre {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlPre) AccessKey(format string, v ...any) HTMLPre {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -36761,6 +37369,9 @@ type HTMLProgress interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLProgress
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLProgress
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLProgress
 
@@ -36956,6 +37567,11 @@ func (e *htmlProgress) Textf(format string, v ...any) HTMLProgress {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlProgress) Key(v any) HTMLProgress {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlProgress) AccessKey(format string, v ...any) HTMLProgress {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -37253,6 +37869,9 @@ type HTMLQ interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLQ
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLQ
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLQ
 
@@ -37445,6 +38064,11 @@ func (e *htmlQ) Textf(format string, v ...any) HTMLQ {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlQ) Key(v any) HTMLQ {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlQ) AccessKey(format string, v ...any) HTMLQ {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -37737,6 +38361,9 @@ type HTMLRp interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLRp
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLRp
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLRp
 
@@ -37926,6 +38553,11 @@ func (e *htmlRp) Textf(format string, v ...any) HTMLRp {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRp) Key(v any) HTMLRp {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlRp) AccessKey(format string, v ...any) HTMLRp {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -38213,6 +38845,9 @@ type HTMLRt interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLRt
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLRt
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLRt
 
@@ -38402,6 +39037,11 @@ func (e *htmlRt) Textf(format string, v ...any) HTMLRt {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRt) Key(v any) HTMLRt {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlRt) AccessKey(format string, v ...any) HTMLRt {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -38689,6 +39329,9 @@ type HTMLRuby interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLRuby
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLRuby
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLRuby
 
@@ -38878,6 +39521,11 @@ func (e *htmlRuby) Textf(format string, v ...any) HTMLRuby {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlRuby) Key(v any) HTMLRuby {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlRuby) AccessKey(format string, v ...any) HTMLRuby {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -39165,6 +39813,9 @@ type HTMLS interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLS
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLS
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLS
 
@@ -39354,6 +40005,11 @@ func (e *htmlS) Textf(format string, v ...any) HTMLS {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlS) Key(v any) HTMLS {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlS) AccessKey(format string, v ...any) HTMLS {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -39641,6 +40297,9 @@ type HTMLSamp interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSamp
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSamp
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSamp
 
@@ -39830,6 +40489,11 @@ func (e *htmlSamp) Textf(format string, v ...any) HTMLSamp {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSamp) Key(v any) HTMLSamp {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSamp) AccessKey(format string, v ...any) HTMLSamp {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -40117,6 +40781,9 @@ type HTMLScript interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLScript
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLScript
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLScript
 
@@ -40225,6 +40892,11 @@ func (e *htmlScript) Textf(format string, v ...any) HTMLScript {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlScript) Key(v any) HTMLScript {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlScript) AccessKey(format string, v ...any) HTMLScript {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -40377,6 +41049,9 @@ type HTMLSection interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSection
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSection
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSection
 
@@ -40566,6 +41241,11 @@ func (e *htmlSection) Textf(format string, v ...any) HTMLSection {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSection) Key(v any) HTMLSection {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSection) AccessKey(format string, v ...any) HTMLSection {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -40853,6 +41533,9 @@ type HTMLSelect interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSelect
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSelect
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSelect
 
@@ -41063,6 +41746,11 @@ func (e *htmlSelect) Textf(format string, v ...any) HTMLSelect {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSelect) Key(v any) HTMLSelect {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSelect) AccessKey(format string, v ...any) HTMLSelect {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -41385,6 +42073,9 @@ type HTMLSmall interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSmall
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSmall
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSmall
 
@@ -41574,6 +42265,11 @@ func (e *htmlSmall) Textf(format string, v ...any) HTMLSmall {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSmall) Key(v any) HTMLSmall {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSmall) AccessKey(format string, v ...any) HTMLSmall {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -41852,6 +42548,9 @@ func (e *htmlSmall) OnWheel(h EventHandler, scope ...any) HTMLSmall {
 type HTMLSource interface {
 	UI
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSource
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSource
 
@@ -42043,6 +42742,11 @@ type htmlSource struct {
 	htmlElement
 }
 
+func (e *htmlSource) Key(v any) HTMLSource {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSource) AccessKey(format string, v ...any) HTMLSource {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -42355,6 +43059,9 @@ type HTMLSpan interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSpan
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSpan
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSpan
 
@@ -42544,6 +43251,11 @@ func (e *htmlSpan) Textf(format string, v ...any) HTMLSpan {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSpan) Key(v any) HTMLSpan {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSpan) AccessKey(format string, v ...any) HTMLSpan {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -42831,6 +43543,9 @@ type HTMLStrong interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLStrong
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLStrong
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLStrong
 
@@ -43020,6 +43735,11 @@ func (e *htmlStrong) Textf(format string, v ...any) HTMLStrong {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlStrong) Key(v any) HTMLStrong {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlStrong) AccessKey(format string, v ...any) HTMLStrong {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -43307,6 +44027,9 @@ type HTMLStyle interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLStyle
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLStyle
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLStyle
 
@@ -43505,6 +44228,11 @@ func (e *htmlStyle) Textf(format string, v ...any) HTMLStyle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlStyle) Key(v any) HTMLStyle {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlStyle) AccessKey(format string, v ...any) HTMLStyle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -43807,6 +44535,9 @@ type HTMLSub interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSub
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSub
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSub
 
@@ -43996,6 +44727,11 @@ func (e *htmlSub) Textf(format string, v ...any) HTMLSub {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSub) Key(v any) HTMLSub {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSub) AccessKey(format string, v ...any) HTMLSub {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -44283,6 +45019,9 @@ type HTMLSummary interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSummary
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSummary
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSummary
 
@@ -44472,6 +45211,11 @@ func (e *htmlSummary) Textf(format string, v ...any) HTMLSummary {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSummary) Key(v any) HTMLSummary {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSummary) AccessKey(format string, v ...any) HTMLSummary {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -44759,6 +45503,9 @@ type HTMLSup interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLSup
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLSup
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLSup
 
@@ -44948,6 +45695,11 @@ func (e *htmlSup) Textf(format string, v ...any) HTMLSup {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlSup) Key(v any) HTMLSup {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlSup) AccessKey(format string, v ...any) HTMLSup {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -45235,6 +45987,9 @@ type HTMLTable interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTable
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTable
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTable
 
@@ -45424,6 +46179,11 @@ func (e *htmlTable) Textf(format string, v ...any) HTMLTable {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTable) Key(v any) HTMLTable {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTable) AccessKey(format string, v ...any) HTMLTable {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -45711,6 +46471,9 @@ type HTMLTBody interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTBody
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTBody
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTBody
 
@@ -45900,6 +46663,11 @@ func (e *htmlTBody) Textf(format string, v ...any) HTMLTBody {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTBody) Key(v any) HTMLTBody {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTBody) AccessKey(format string, v ...any) HTMLTBody {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -46187,6 +46955,9 @@ type HTMLTd interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTd
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTd
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTd
 
@@ -46385,6 +47156,11 @@ func (e *htmlTd) Textf(format string, v ...any) HTMLTd {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTd) Key(v any) HTMLTd {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTd) AccessKey(format string, v ...any) HTMLTd {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -46687,6 +47463,9 @@ type HTMLTemplate interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTemplate
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTemplate
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTemplate
 
@@ -46774,6 +47553,11 @@ func (e *htmlTemplate) Textf(format string, v ...any) HTMLTemplate {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTemplate) Key(v any) HTMLTemplate {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTemplate) AccessKey(format string, v ...any) HTMLTemplate {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -46891,6 +47675,9 @@ type HTMLTextarea interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTextarea
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTextarea
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTextarea
 
@@ -47118,6 +47905,11 @@ func (e *htmlTextarea) Textf(format string, v ...any) HTMLTextarea {
 	return e
 }
 
+func (e *htmlTextarea) Key(v any) HTMLTextarea {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTextarea) AccessKey(format string, v ...any) HTMLTextarea {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -47465,6 +48257,9 @@ type HTMLTFoot interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTFoot
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTFoot
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTFoot
 
@@ -47654,6 +48449,11 @@ func (e *htmlTFoot) Textf(format string, v ...any) HTMLTFoot {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTFoot) Key(v any) HTMLTFoot {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTFoot) AccessKey(format string, v ...any) HTMLTFoot {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -47941,6 +48741,9 @@ type HTMLTh interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTh
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTh
+
 	// Abbr specifies an abbreviated version of the content in a header cell with the given format and values.
 	Abbr(format string, v ...any) HTMLTh
 
@@ -48145,6 +48948,11 @@ func (e *htmlTh) Textf(format string, v ...any) HTMLTh {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTh) Key(v any) HTMLTh {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTh) Abbr(format string, v ...any) HTMLTh {
 	e.setAttr("abbr", FormatString(format, v...))
 	return e
@@ -48457,6 +49265,9 @@ type HTMLTHead interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTHead
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTHead
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTHead
 
@@ -48646,6 +49457,11 @@ func (e *htmlTHead) Textf(format string, v ...any) HTMLTHead {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTHead) Key(v any) HTMLTHead {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTHead) AccessKey(format string, v ...any) HTMLTHead {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -48933,6 +49749,9 @@ type HTMLTime interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTime
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTime
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTime
 
@@ -49125,6 +49944,11 @@ func (e *htmlTime) Textf(format string, v ...any) HTMLTime {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTime) Key(v any) HTMLTime {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTime) AccessKey(format string, v ...any) HTMLTime {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -49417,6 +50241,9 @@ type HTMLTitle interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTitle
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTitle
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTitle
 
@@ -49504,6 +50331,11 @@ func (e *htmlTitle) Textf(format string, v ...any) HTMLTitle {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTitle) Key(v any) HTMLTitle {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTitle) AccessKey(format string, v ...any) HTMLTitle {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -49621,6 +50453,9 @@ type HTMLTr interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLTr
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLTr
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLTr
 
@@ -49810,6 +50645,11 @@ func (e *htmlTr) Textf(format string, v ...any) HTMLTr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlTr) Key(v any) HTMLTr {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlTr) AccessKey(format string, v ...any) HTMLTr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -50097,6 +50937,9 @@ type HTMLU interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLU
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLU
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLU
 
@@ -50286,6 +51129,11 @@ func (e *htmlU) Textf(format string, v ...any) HTMLU {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlU) Key(v any) HTMLU {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlU) AccessKey(format string, v ...any) HTMLU {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -50573,6 +51421,9 @@ type HTMLUl interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLUl
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLUl
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLUl
 
@@ -50762,6 +51613,11 @@ func (e *htmlUl) Textf(format string, v ...any) HTMLUl {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlUl) Key(v any) HTMLUl {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlUl) AccessKey(format string, v ...any) HTMLUl {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -51049,6 +51905,9 @@ type HTMLVar interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLVar
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLVar
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLVar
 
@@ -51238,6 +52097,11 @@ func (e *htmlVar) Textf(format string, v ...any) HTMLVar {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlVar) Key(v any) HTMLVar {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlVar) AccessKey(format string, v ...any) HTMLVar {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -51525,6 +52389,9 @@ type HTMLVideo interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLVideo
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLVideo
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLVideo
 
@@ -51813,6 +52680,11 @@ func (e *htmlVideo) Textf(format string, v ...any) HTMLVideo {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlVideo) Key(v any) HTMLVideo {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlVideo) AccessKey(format string, v ...any) HTMLVideo {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
@@ -52265,6 +53137,9 @@ type HTMLWbr interface {
 	// Textf sets the content of the element with the given format and values.
 	Textf(format string, v ...any) HTMLWbr
 
+	// Key sets the value that identifies the element among its siblings when its parent is updated. The value must be comparable.
+	Key(v any) HTMLWbr
+
 	// AccessKey specifies a shortcut key with the given format and values to activate/focus an element.
 	AccessKey(format string, v ...any) HTMLWbr
 
@@ -52454,6 +53329,11 @@ func (e *htmlWbr) Textf(format string, v ...any) HTMLWbr {
 	return e.Body(Textf(format, v...))
 }
 
+func (e *htmlWbr) Key(v any) HTMLWbr {
+	e.setKey(v)
+	return e
+}
+
 func (e *htmlWbr) AccessKey(format string, v ...any) HTMLWbr {
 	e.setAttr("accesskey", FormatString(format, v...))
 	return e
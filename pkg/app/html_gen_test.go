@@ -38,6 +38,7 @@ func TestA(t *testing.T) {
 	elem.Target("hello %v", 42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -103,6 +104,7 @@ func TestAbbr(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -168,6 +170,7 @@ func TestAddress(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -243,6 +246,7 @@ func TestArea(t *testing.T) {
 	elem.Target("hello %v", 42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -306,6 +310,7 @@ func TestArticle(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -371,6 +376,7 @@ func TestAside(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -447,6 +453,7 @@ func TestAudio(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -535,6 +542,7 @@ func TestB(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -602,6 +610,7 @@ func TestBase(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Target("hello %v", 42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -665,6 +674,7 @@ func TestBdi(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -730,6 +740,7 @@ func TestBdo(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -796,6 +807,7 @@ func TestBlockquote(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -861,6 +873,7 @@ func TestBody(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -940,6 +953,7 @@ func TestBr(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1017,6 +1031,7 @@ func TestButton(t *testing.T) {
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1084,6 +1099,7 @@ func TestCanvas(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1149,6 +1165,7 @@ func TestCaption(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1214,6 +1231,7 @@ func TestCite(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1279,6 +1297,7 @@ func TestCode(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1345,6 +1364,7 @@ func TestCol(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1409,6 +1429,7 @@ func TestColGroup(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1475,6 +1496,7 @@ func TestData(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 	elem.Text("hello")
 	elem.Textf("hello %s", "Maxence")
 }
@@ -1503,6 +1525,7 @@ func TestDataList(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1568,6 +1591,7 @@ func TestDd(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1635,6 +1659,7 @@ func TestDel(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1702,6 +1727,7 @@ func TestDetails(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1768,6 +1794,7 @@ func TestDfn(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1835,6 +1862,7 @@ func TestDialog(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1900,6 +1928,7 @@ func TestDiv(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -1965,6 +1994,7 @@ func TestDl(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2030,6 +2060,7 @@ func TestDt(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2096,6 +2127,7 @@ func TestElem(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.XMLNS("http://www.w3.org/2000/svg")
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2162,6 +2194,7 @@ func TestElemSelfClosing(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.XMLNS("http://www.w3.org/2000/svg")
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2225,6 +2258,7 @@ func TestEm(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2294,6 +2328,7 @@ func TestEmbed(t *testing.T) {
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2384,6 +2419,7 @@ func TestFieldSet(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2449,6 +2485,7 @@ func TestFigCaption(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2514,6 +2551,7 @@ func TestFigure(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2579,6 +2617,7 @@ func TestFooter(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2654,6 +2693,7 @@ func TestForm(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Target("hello %v", 42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2719,6 +2759,7 @@ func TestH1(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2784,6 +2825,7 @@ func TestH2(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2849,6 +2891,7 @@ func TestH3(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2914,6 +2957,7 @@ func TestH4(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -2979,6 +3023,7 @@ func TestH5(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3044,6 +3089,7 @@ func TestH6(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3109,6 +3155,7 @@ func TestHead(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 	elem.Text("hello")
 	elem.Textf("hello %s", "Maxence")
 }
@@ -3137,6 +3184,7 @@ func TestHeader(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3202,6 +3250,7 @@ func TestHr(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3265,6 +3314,7 @@ func TestHtml(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 	elem.privateBody(Text("hello"))
 }
 
@@ -3292,6 +3342,7 @@ func TestI(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3370,6 +3421,7 @@ func TestIFrame(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3447,6 +3499,7 @@ func TestImg(t *testing.T) {
 	elem.Title("hello %v", 42)
 	elem.UseMap("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3573,6 +3626,7 @@ func TestInput(t *testing.T) {
 	elem.Type("hello %v", 42)
 	elem.Value(42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3637,6 +3691,7 @@ func TestIns(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3702,6 +3757,7 @@ func TestKbd(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3769,6 +3825,7 @@ func TestLabel(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3834,6 +3891,7 @@ func TestLegend(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3900,6 +3958,7 @@ func TestLi(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -3974,6 +4033,7 @@ func TestLink(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4038,6 +4098,7 @@ func TestMain(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4104,6 +4165,7 @@ func TestMap(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4169,6 +4231,7 @@ func TestMark(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4239,6 +4302,7 @@ func TestMeta(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 }
 
 func TestMeter(t *testing.T) {
@@ -4272,6 +4336,7 @@ func TestMeter(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4337,6 +4402,7 @@ func TestNav(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4402,6 +4468,7 @@ func TestNoScript(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 	elem.Text("hello")
 	elem.Textf("hello %s", "Maxence")
 }
@@ -4437,6 +4504,7 @@ func TestObject(t *testing.T) {
 	elem.Type("hello %v", 42)
 	elem.UseMap("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4529,6 +4597,7 @@ func TestOl(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4597,6 +4666,7 @@ func TestOptGroup(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4668,6 +4738,7 @@ func TestOption(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4736,6 +4807,7 @@ func TestOutput(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4801,6 +4873,7 @@ func TestP(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4868,6 +4941,7 @@ func TestParam(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4931,6 +5005,7 @@ func TestPicture(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -4996,6 +5071,7 @@ func TestPre(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5063,6 +5139,7 @@ func TestProgress(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Value(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5129,6 +5206,7 @@ func TestQ(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5194,6 +5272,7 @@ func TestRp(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5259,6 +5338,7 @@ func TestRt(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5324,6 +5404,7 @@ func TestRuby(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5389,6 +5470,7 @@ func TestS(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5454,6 +5536,7 @@ func TestSamp(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5527,6 +5610,7 @@ func TestScript(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5559,6 +5643,7 @@ func TestSection(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5635,6 +5720,7 @@ func TestSelect(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5700,6 +5786,7 @@ func TestSmall(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5770,6 +5857,7 @@ func TestSource(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5833,6 +5921,7 @@ func TestSpan(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5898,6 +5987,7 @@ func TestStrong(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -5965,6 +6055,7 @@ func TestStyle(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Type("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6031,6 +6122,7 @@ func TestSub(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6096,6 +6188,7 @@ func TestSummary(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6161,6 +6254,7 @@ func TestSup(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6226,6 +6320,7 @@ func TestTable(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6291,6 +6386,7 @@ func TestTBody(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6359,6 +6455,7 @@ func TestTd(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6424,6 +6521,7 @@ func TestTemplate(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 	elem.Text("hello")
 	elem.Textf("hello %s", "Maxence")
 }
@@ -6468,6 +6566,7 @@ func TestTextarea(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Wrap("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6533,6 +6632,7 @@ func TestTFoot(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6603,6 +6703,7 @@ func TestTh(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6668,6 +6769,7 @@ func TestTHead(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6734,6 +6836,7 @@ func TestTime(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6799,6 +6902,7 @@ func TestTitle(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 	elem.Text("hello")
 	elem.Textf("hello %s", "Maxence")
 }
@@ -6827,6 +6931,7 @@ func TestTr(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6892,6 +6997,7 @@ func TestU(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -6957,6 +7063,7 @@ func TestUl(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -7022,6 +7129,7 @@ func TestVar(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -7101,6 +7209,7 @@ func TestVideo(t *testing.T) {
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
 	elem.Width(42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
@@ -7189,6 +7298,7 @@ func TestWbr(t *testing.T) {
 	elem.Styles(map[string]string{"color": "pink"})
 	elem.TabIndex(42)
 	elem.Title("hello %v", 42)
+	elem.Key("foo")
 
 	h := func(ctx Context, e Event) {}
 	elem.On("click", h)
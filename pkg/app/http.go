@@ -2,12 +2,14 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha1"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -61,6 +63,60 @@ type Handler struct {
 	// DEFAULT: en.
 	Lang string
 
+	// The identifier of the web application, relative to its origin. It lets
+	// browsers recognize the installed app when its start URL changes.
+	//
+	// Default: "", which identifies the app by its start URL.
+	ManifestID string
+
+	// The display modes that the installed app prefers over "standalone", by
+	// order of preference, such as "window-controls-overlay" or "tabbed".
+	DisplayOverride []string
+
+	// The categories the web application belongs to, such as "productivity"
+	// or "games". They are used as hints by app stores.
+	Categories []string
+
+	// The shortcuts to the app routes that are displayed by the operating
+	// system, such as in the context menu of the app icon.
+	Shortcuts []ManifestShortcut
+
+	// The screenshots of the app that are displayed by app stores and install
+	// dialogs.
+	Screenshots []ManifestScreenshot
+
+	// The share target that lets the installed app receive the data shared
+	// from other apps. Components are notified with the shared data through the
+	// ShareTargetHandler interface.
+	//
+	// Default is nil, which does not register the app as a share target.
+	ShareTarget *ShareTarget
+
+	// The files that the installed app can be launched with from the operating
+	// system. Components are notified with the launched files through the
+	// FileLaunchHandler interface.
+	FileHandlers []FileHandler
+
+	// The URL schemes, such as "web+music", that are handled by the installed
+	// app.
+	ProtocolHandlers []ProtocolHandler
+
+	// The web app manifests translated for other languages than Lang, by
+	// language tag. Translated manifests are served from
+	// "/manifest.<lang>.webmanifest" and linked by the pages whose language
+	// matches their language tag, or its primary subtag.
+	//
+	// eg:
+	//  app.Handler{
+	//      LocalizedManifests: map[string]app.LocalizedManifest{
+	//          "fr": {
+	//              Name:        "Bonjour",
+	//              Description: "Une application qui dit bonjour.",
+	//          },
+	//      },
+	//  },
+	LocalizedManifests map[string]LocalizedManifest
+
 	// The custom libraries to load with the page.
 	Libraries []Library
 
@@ -137,6 +193,53 @@ type Handler struct {
 	// Default: Body().
 	Body func() HTMLBody
 
+	// Reports whether the app reuses the server-side pre-rendered HTML when it
+	// starts in a web browser, rather than rebuilding the page from scratch.
+	//
+	// When enabled, the DOM nodes that match the UI elements of the first
+	// displayed page are linked to them and only the differences are patched,
+	// which prevents the page from flashing on load.
+	//
+	// Default is false.
+	Hydrate bool
+
+	// The maximum duration of the server-side pre-rendering of a page. When it
+	// is exceeded, the asynchronous operations launched with Context.Async are
+	// canceled through their context, the page is rendered in its current
+	// state and the components that did not finish are logged.
+	//
+	// The pre-rendering is also canceled when the request context is done.
+	//
+	// Default is 0, which does not set any limit.
+	PreRenderTimeout time.Duration
+
+	// Reports whether pages are streamed while they are server-side
+	// pre-rendered.
+	//
+	// Note that the status code, the headers and the redirections that are set
+	// with Page after the page head has been sent are ignored.
+	//
+	// When enabled, the page head and the content that does not depend on
+	// asynchronous operations are sent right away. Components that are waiting
+	// for operations launched with Context.Async are first sent as they are,
+	// then their updated content is sent in a later chunk that replaces it once
+	// their operations are done.
+	//
+	// Default is false.
+	StreamPreRendering bool
+
+	// Reports whether the app routes are read from and written to the URL
+	// fragment, with URLs such as "https://example.com/#/users/42", rather than
+	// the URL path.
+	//
+	// It is to be enabled when the app is served by a host that does not
+	// fallback to index.html for unknown paths, since every route is then
+	// displayed from the root page. Links to "/users/42" are displayed as
+	// "/#/users/42".
+	//
+	// Default is false.
+	HashRouting bool
+
 	// The interval between each app auto-update while running in a web browser.
 	// Zero or negative values deactivates the auto-update mechanism.
 	//
@@ -148,6 +251,9 @@ type Handler struct {
 	// Reserved keys:
 	// - GOAPP_VERSION
 	// - GOAPP_GOAPP_STATIC_RESOURCES_URL
+	// - GOAPP_HYDRATE
+	// - GOAPP_HASH_ROUTING
+	// - GOAPP_BASE_PATH
 	Env Environment
 
 	// The URLs that are launched in the app tab or window.
@@ -168,6 +274,36 @@ type Handler struct {
 	// are proxied by default are /robots.txt, /sitemap.xml and /ads.txt.
 	ProxyResources []ProxyResource
 
+	// Reports whether the package resources, the libraries and the static
+	// resources that are located in a local directory are served from URLs
+	// that contain a hash of their content, such as
+	// "/web/main.1a2b3c4d5e6f7a8b.css" for "/web/main.css".
+	//
+	// Fingerprinted resources are served with a Cache-Control header that lets
+	// browsers cache them forever, since their URL changes with their content.
+	// Pages, app-worker.js and manifest.webmanifest are still revalidated on
+	// each request.
+	//
+	// Fingerprinted static resources are the ones defined in Styles, Scripts,
+	// Fonts, CacheableResources and Icon, along with app.wasm. They are hashed
+	// once when the handler is initialized and must then not be modified while
+	// the server is running.
+	//
+	// Default is false.
+	FingerprintResources bool
+
+	// Reports whether the script and style elements of the pages have an
+	// integrity attribute that lets browsers verify that their resources have
+	// not been tampered with.
+	//
+	// The SHA-384 digests of the package resources, the libraries and the
+	// scripts and styles that are located in a local static resources directory
+	// are computed once when the handler is initialized. Local static resources
+	// must then not be modified while the server is running.
+	//
+	// Default is false.
+	SubresourceIntegrity bool
+
 	// The resource provider that provides static resources. Static resources
 	// are always accessed from a path that starts with "/web/".
 	//
@@ -177,6 +313,39 @@ type Handler struct {
 	// Default: LocalDir("")
 	Resources ResourceProvider
 
+	// The Content-Security-Policy header that is sent with the pages. A nonce
+	// is generated for each page request and set on the script, style and link
+	// elements that are emitted in the page head. Components get it with
+	// Page.Nonce().
+	//
+	// eg:
+	//  app.Handler{
+	//      ContentSecurityPolicy: app.CSP().
+	//          DefaultSrc("'self'").
+	//          ImgSrc("'self'", "data:"),
+	//  },
+	//
+	// Default is nil, which does not send any Content-Security-Policy header.
+	ContentSecurityPolicy *ContentSecurityPolicy
+
+	// The URL path prefix under which the app is mounted, such as "/admin"
+	// when the app is served behind a reverse proxy that shares its domain with
+	// other apps.
+	//
+	// When set, the generated URLs, the routes, the service worker scope and
+	// the client navigations are prefixed with it. Requests are accepted with
+	// or without the prefix, which allows reverse proxies to strip it.
+	//
+	// Routes are still registered without the prefix, eg: "/users" is served
+	// from "/admin/users". App paths, such as the ones returned by URLFor, are
+	// written without it as well: it is added once to the absolute paths of
+	// href attributes, navigations, navigation middleware redirections and
+	// page redirections. The URLs returned by Page.URL and given to navigation
+	// middlewares include it.
+	//
+	// Default is "", which mounts the app at the root.
+	BasePath string
+
 	// The version number. This is used in order to update the PWA application
 	// in the browser. It must be set when deployed on a live system in order to
 	// prevent recurring updates.
@@ -189,6 +358,9 @@ type Handler struct {
 	//
 	// Content length finding falls back to the Content-Length HTTP header when
 	// no content length is found with the defined header.
+	//
+	// Default: X-Uncompressed-Content-Length, which is set when a precompressed
+	// app.wasm.br or app.wasm.gz file is served from a local directory.
 	WasmContentLengthHeader string
 
 	// The template used to generate app-worker.js. The template follows the
@@ -200,26 +372,33 @@ type Handler struct {
 	ServiceWorkerTemplate string
 
 	once                 sync.Once
-	etag                 string
 	libraries            map[string][]byte
 	proxyResources       map[string]ProxyResource
 	cachedProxyResources *memoryCache
 	cachedPWAResources   *memoryCache
+	integrities          map[string]string
+	fingerprints         map[string]string
+	fingerprintedPaths   map[string]string
 }
 
 func (h *Handler) init() {
+	h.initBasePath()
 	h.initVersion()
+	h.initWasmContentLengthHeader()
 	h.initStaticResources()
 	h.initImage()
 	h.initLibraries()
+	h.initFingerprints()
 	h.initLinks()
 	h.initScripts()
 	h.initServiceWorker()
 	h.initCacheableResources()
 	h.initIcon()
 	h.initPWA()
+	h.initManifest()
 	h.initPageContent()
 	h.initPWAResources()
+	h.initSubresourceIntegrities()
 	h.initProxyResources()
 }
 
@@ -228,7 +407,16 @@ func (h *Handler) initVersion() {
 		t := time.Now().UTC().String()
 		h.Version = fmt.Sprintf(`%x`, sha1.Sum([]byte(t)))
 	}
-	h.etag = `"` + h.Version + `"`
+}
+
+func (h *Handler) initWasmContentLengthHeader() {
+	if h.WasmContentLengthHeader == "" {
+		h.WasmContentLengthHeader = uncompressedContentLengthHeader
+	}
+}
+
+func (h *Handler) initBasePath() {
+	h.BasePath = normalizeBasePath(h.BasePath)
 }
 
 func (h *Handler) initStaticResources() {
@@ -255,6 +443,34 @@ func (h *Handler) initLibraries() {
 	h.libraries = libs
 }
 
+func (h *Handler) initFingerprints() {
+	if !h.FingerprintResources {
+		return
+	}
+
+	h.addFingerprint("/app.css", []byte(appCSS))
+	h.addFingerprint("/wasm_exec.js", []byte(wasmExecJS()))
+	for path, styles := range h.libraries {
+		h.addFingerprint(path, styles)
+	}
+
+	icons := []string{
+		h.Icon.Default,
+		h.Icon.Large,
+		h.Icon.SVG,
+		h.Icon.AppleTouch,
+	}
+	for _, resources := range [][]string{h.Styles, h.Scripts, h.Fonts, h.CacheableResources, icons} {
+		for _, src := range resources {
+			h.addLocalStaticResourceFingerprint(src)
+		}
+	}
+
+	if strings.HasSuffix(h.Resources.AppWASM(), "/web/app.wasm") {
+		h.addLocalStaticResourceFingerprint("/web/app.wasm")
+	}
+}
+
 func (h *Handler) initLinks() {
 	for i, path := range h.Preconnect {
 		h.Preconnect[i] = h.resolveStaticPath(path)
@@ -351,7 +567,15 @@ func (h *Handler) initPageContent() {
 }
 
 func (h *Handler) initPWAResources() {
-	h.cachedPWAResources = newMemoryCache(5)
+	h.cachedPWAResources = newMemoryCache(5 + len(h.libraries) + len(h.LocalizedManifests))
+
+	for path, styles := range h.libraries {
+		h.cachedPWAResources.Set(cacheItem{
+			Path:        path,
+			ContentType: "text/css",
+			Body:        styles,
+		})
+	}
 
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/wasm_exec.js",
@@ -359,11 +583,15 @@ func (h *Handler) initPWAResources() {
 		Body:        []byte(wasmExecJS()),
 	})
 
+	appJS := h.makeAppJS()
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/app.js",
 		ContentType: "application/javascript",
-		Body:        h.makeAppJS(),
+		Body:        appJS,
 	})
+	if h.FingerprintResources {
+		h.addFingerprint("/app.js", appJS)
+	}
 
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/app-worker.js",
@@ -374,9 +602,17 @@ func (h *Handler) initPWAResources() {
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/manifest.webmanifest",
 		ContentType: "application/manifest+json",
-		Body:        h.makeManifestJSON(),
+		Body:        h.makeManifestJSON(h.Lang),
 	})
 
+	for lang := range h.LocalizedManifests {
+		h.cachedPWAResources.Set(cacheItem{
+			Path:        h.localizedManifestPath(lang),
+			ContentType: "application/manifest+json",
+			Body:        h.makeManifestJSON(lang),
+		})
+	}
+
 	h.cachedPWAResources.Set(cacheItem{
 		Path:        "/app.css",
 		ContentType: "text/css",
@@ -391,8 +627,11 @@ func (h *Handler) makeAppJS() []byte {
 	internalURLs, _ := json.Marshal(h.InternalURLs)
 	h.Env["GOAPP_INTERNAL_URLS"] = string(internalURLs)
 	h.Env["GOAPP_VERSION"] = h.Version
-	h.Env["GOAPP_STATIC_RESOURCES_URL"] = h.Resources.Static()
-	h.Env["GOAPP_ROOT_PREFIX"] = h.Resources.Package()
+	h.Env["GOAPP_STATIC_RESOURCES_URL"] = h.staticResourcesURL()
+	h.Env["GOAPP_ROOT_PREFIX"] = h.rootPrefix()
+	h.Env["GOAPP_BASE_PATH"] = h.BasePath
+	h.Env["GOAPP_HYDRATE"] = strconv.FormatBool(h.Hydrate)
+	h.Env["GOAPP_HASH_ROUTING"] = strconv.FormatBool(h.HashRouting)
 
 	for k, v := range h.Env {
 		if err := os.Setenv(k, v); err != nil {
@@ -416,7 +655,7 @@ func (h *Handler) makeAppJS() []byte {
 		}{
 			Env:                     jsonString(h.Env),
 			LoadingLabel:            h.LoadingLabel,
-			Wasm:                    h.Resources.AppWASM(),
+			Wasm:                    h.appWASMPath(),
 			WasmContentLengthHeader: h.WasmContentLengthHeader,
 			WorkerJS:                h.resolvePackagePath("/app-worker.js"),
 			AutoUpdateInterval:      h.AutoUpdateInterval.Milliseconds(),
@@ -444,8 +683,11 @@ func (h *Handler) makeAppWorkerJS() []byte {
 		h.resolvePackagePath("/manifest.webmanifest"),
 		h.resolvePackagePath("/wasm_exec.js"),
 		h.resolvePackagePath("/"),
-		h.Resources.AppWASM(),
+		h.appWASMPath(),
 	)
+	for lang := range h.LocalizedManifests {
+		setResources(h.resolvePackagePath(h.localizedManifestPath(lang)))
+	}
 	setResources(h.Icon.Default, h.Icon.Large, h.Icon.AppleTouch)
 	setResources(h.Styles...)
 	setResources(h.Fonts...)
@@ -475,46 +717,41 @@ func (h *Handler) makeAppWorkerJS() []byte {
 	return b.Bytes()
 }
 
-func (h *Handler) makeManifestJSON() []byte {
-	normalize := func(s string) string {
-		if !strings.HasPrefix(s, "/") {
-			s = "/" + s
-		}
-		if !strings.HasSuffix(s, "/") {
-			s += "/"
+func (h *Handler) initSubresourceIntegrities() {
+	if !h.SubresourceIntegrity {
+		return
+	}
+
+	h.integrities = make(map[string]string)
+	for _, path := range []string{"/wasm_exec.js", "/app.js"} {
+		if i, ok := h.cachedPWAResources.Get(path); ok {
+			h.integrities[h.resolvePackagePath(path)] = integrityOf(i.Body)
 		}
-		return s
+	}
+	h.integrities[h.resolvePackagePath("/app.css")] = integrityOf([]byte(appCSS))
+	for path, styles := range h.libraries {
+		h.integrities[h.resolvePackagePath(path)] = integrityOf(styles)
 	}
 
-	var b bytes.Buffer
-	if err := template.
-		Must(template.New("manifest.webmanifest").Parse(manifestJSON)).
-		Execute(&b, struct {
-			ShortName       string
-			Name            string
-			Description     string
-			DefaultIcon     string
-			LargeIcon       string
-			SVGIcon         string
-			BackgroundColor string
-			ThemeColor      string
-			Scope           string
-			StartURL        string
-		}{
-			ShortName:       h.ShortName,
-			Name:            h.Name,
-			Description:     h.Description,
-			DefaultIcon:     h.Icon.Default,
-			LargeIcon:       h.Icon.Large,
-			SVGIcon:         h.Icon.SVG,
-			BackgroundColor: h.BackgroundColor,
-			ThemeColor:      h.ThemeColor,
-			Scope:           normalize(h.Resources.Package()),
-			StartURL:        normalize(h.Resources.Package()),
-		}); err != nil {
-		panic(errors.New("initializing manifest.webmanifest failed").Wrap(err))
+	for _, resources := range [][]string{h.Styles, h.Scripts} {
+		for _, src := range resources {
+			url, _, _ := parseSrc(src)
+			if _, ok := h.integrities[url]; ok {
+				continue
+			}
+
+			b, err := h.readLocalStaticResource(url)
+			if err != nil {
+				Log(errors.New("computing subresource integrity failed").
+					WithTag("url", url).
+					Wrap(err))
+				continue
+			}
+			if b != nil {
+				h.integrities[url] = integrityOf(b)
+			}
+		}
 	}
-	return b.Bytes()
 }
 
 func (h *Handler) initProxyResources() {
@@ -567,15 +804,16 @@ func (h *Handler) initProxyResources() {
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.once.Do(h.init)
 
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("ETag", h.etag)
-
-	etag := r.Header.Get("If-None-Match")
-	if etag == h.etag {
-		w.WriteHeader(http.StatusNotModified)
-		return
+	if h.BasePath != "" && hasBasePath(h.BasePath, r.URL.Path) {
+		r = requestWithPath(r, trimBasePath(h.BasePath, r.URL.Path))
 	}
 
+	if path, ok := h.fingerprintedPaths[r.URL.Path]; ok {
+		w.Header().Set("Cache-Control", immutableCacheControl)
+		r = requestWithPath(r, path)
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
 	path := r.URL.Path
 
 	fileHandler, isServingStaticResources := h.Resources.(http.Handler)
@@ -605,7 +843,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if res, ok := h.cachedPWAResources.Get(path); ok {
-		h.serveCachedItem(w, res)
+		h.serveCachedItem(w, r, res)
 		return
 	}
 
@@ -614,24 +852,80 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if library, ok := h.libraries[path]; ok {
-		h.serveLibrary(w, r, library)
-		return
-	}
-
 	h.servePage(w, r)
 }
 
-func (h *Handler) serveCachedItem(w http.ResponseWriter, i cacheItem) {
-	w.Header().Set("Content-Length", strconv.Itoa(i.Len()))
+func (h *Handler) serveCachedItem(w http.ResponseWriter, r *http.Request, i cacheItem) {
+	body := i.Body
+	encoding := i.ContentEncoding
+	etag := i.ETag
+
+	if len(i.GzipBody) != 0 {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if acceptsEncoding(r, "gzip") {
+			body = i.GzipBody
+			encoding = "gzip"
+			etag = strings.TrimSuffix(etag, `"`) + `-gzip"`
+		}
+	}
+
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+		if matchesETag(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
 	w.Header().Set("Content-Type", i.ContentType)
 
-	if i.ContentEncoding != "" {
-		w.Header().Set("Content-Encoding", i.ContentEncoding)
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
 	}
 
 	w.WriteHeader(http.StatusOK)
-	w.Write(i.Body)
+	w.Write(body)
+}
+
+// isPageRequest reports whether the given request is made to display a page,
+// as opposed to loading an asset such as an image or a script.
+func isPageRequest(r *http.Request) bool {
+	if accept := r.Header.Get("Accept"); strings.Contains(accept, "text/html") {
+		return true
+	}
+	return path.Ext(r.URL.Path) == ""
+}
+
+// matchesETag reports whether the If-None-Match header of the given request
+// matches the given entity tag. The header can contain a list of entity tags
+// that are compared with the weak comparison.
+func matchesETag(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// requestWithPath returns a shallow copy of the given request with its URL path
+// replaced by the given one.
+func requestWithPath(r *http.Request, path string) *http.Request {
+	u := *r.URL
+	u.Path = path
+	u.RawPath = ""
+
+	r2 := *r
+	r2.URL = &u
+	return &r2
 }
 
 func (h *Handler) serveProxyResource(resource ProxyResource, w http.ResponseWriter, r *http.Request) {
@@ -643,13 +937,13 @@ func (h *Handler) serveProxyResource(resource ProxyResource, w http.ResponseWrit
 		} else {
 			protocol = "http://"
 		}
-		u = protocol + r.Host + resource.ResourcePath
+		u = protocol + r.Host + h.BasePath + resource.ResourcePath
 	} else {
 		u = h.Resources.Static() + resource.ResourcePath
 	}
 
 	if i, ok := h.cachedProxyResources.Get(resource.Path); ok {
-		h.serveCachedItem(w, i)
+		h.serveCachedItem(w, r, i)
 		return
 	}
 
@@ -690,22 +984,94 @@ func (h *Handler) serveProxyResource(resource ProxyResource, w http.ResponseWrit
 		Body:            body,
 	}
 	h.cachedProxyResources.Set(item)
-	h.serveCachedItem(w, item)
+	h.serveCachedItem(w, r, item)
 }
 
+// servePage pre-renders the page at the request path. Pages are not given an
+// ETag since their content depends on the request, such as its cookies or
+// headers, and differs with each Content-Security-Policy nonce.
 func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
-	content, ok := routes.createComponent(r.URL.Path)
+	route, ok := routes.createRoute(r.URL.Path)
+	statusCode := 0
 	if !ok {
-		http.NotFound(w, r)
+		if !isPageRequest(r) {
+			http.NotFound(w, r)
+			return
+		}
+		route = routes.createNotFoundRoute(r.URL.Path)
+		statusCode = http.StatusNotFound
+	}
+
+	pw := &pageResponseWriter{ResponseWriter: w}
+	err := h.renderPage(pw, r, route, statusCode, false)
+	if err == nil {
+		return
+	}
+	Log(errors.New("pre-rendering page failed").
+		WithTag("path", r.URL.Path).
+		Wrap(err))
+	if pw.written {
 		return
 	}
 
-	url := *r.URL
+	if route, ok := routes.createErrorRoute(err); ok {
+		err := h.renderPage(pw, r, route, http.StatusInternalServerError, true)
+		if err == nil {
+			return
+		}
+		Log(errors.New("pre-rendering error page failed").
+			WithTag("path", r.URL.Path).
+			Wrap(err))
+		if pw.written {
+			return
+		}
+	}
+
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// renderPage pre-renders the given route and writes it. Navigation middlewares
+// and route loaders are not executed for error pages. Panics that occur while
+// pre-rendering are returned as errors.
+func (h *Handler) renderPage(w http.ResponseWriter, r *http.Request, route []Composer, statusCode int, isErrorPage bool) (err error) {
+	var closeDispatcher func()
+	defer func() {
+		if v := recover(); v != nil {
+			err = recoveredError("pre-rendering page panicked", v)
+			if closeDispatcher != nil {
+				closeDispatcher()
+			}
+		}
+	}()
+
+	// Share target requests are parsed before the request is cloned for the
+	// page, which then carries the parsed form.
+	var shareTargetData *ShareTargetData
+	if !isErrorPage {
+		data, ok, err := h.readShareTargetData(w, r)
+		if r.MultipartForm != nil {
+			// The temporary files are removed here since the server only
+			// removes the ones of the request it has created, which differs
+			// from r when its path has been rewritten.
+			defer r.MultipartForm.RemoveAll()
+		}
+		if err != nil {
+			Log(errors.New("reading share target data failed").
+				WithTag("path", r.URL.Path).
+				Wrap(err))
+		}
+		if ok {
+			shareTargetData = &data
+		}
+	}
+
+	url := *withBasePath(h.BasePath, r.URL)
 	url.Host = r.Host
 	url.Scheme = "http"
 
 	page := requestPage{
 		url:                   &url,
+		request:               pageRequest(r),
 		resolveStaticResource: h.resolveStaticPath,
 	}
 	page.SetTitle(h.Title)
@@ -715,13 +1081,33 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 	page.SetKeywords(h.Keywords...)
 	page.SetLoadingLabel(strings.ReplaceAll(h.LoadingLabel, "{progress}", "0"))
 	page.SetImage(h.Image)
+	page.statusCode = statusCode
+	page.shareTargetData = shareTargetData
+
+	if h.ContentSecurityPolicy != nil {
+		page.nonce = newCSPNonce()
+		if name, value := h.ContentSecurityPolicy.header(page.nonce); value != "" {
+			w.Header().Set(name, value)
+		}
+	}
+
+	ctx := r.Context()
+	if h.PreRenderTimeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, h.PreRenderTimeout)
+		defer cancel()
+	}
 
-	disp := engine{
+	disp := &engine{
 		Page:                   &page,
 		IsServerSide:           true,
 		StaticResourceResolver: h.resolveStaticPath,
 		ActionHandlers:         actionHandlers,
+		BaseContext:            ctx,
+		BasePath:               h.BasePath,
+		asyncs:                 newAsyncTracker(h.StreamPreRendering),
 	}
+
 	body := h.Body().privateBody(
 		Div(), // Pre-rendeging placeholder
 		Aside().
@@ -738,7 +1124,7 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					Text(page.loadingLabel),
 			),
 	)
-	if err := mount(&disp, body); err != nil {
+	if err := mount(disp, body); err != nil {
 		panic(errors.New("mounting pre-rendering container failed").
 			WithTag("server-side", disp.isServerSide()).
 			WithTag("body-type", reflect.TypeOf(disp.Body)).
@@ -746,23 +1132,191 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 	}
 	disp.Body = body
 	disp.init()
-	defer disp.Close()
+	closeDispatcher = disp.Close
+
+	if !isErrorPage && !guardPage(w, r, disp) {
+		disp.Close()
+		return nil
+	}
+
+	var loaded *loaderResult
+	if loader, ok := routes.routeLoader(r.URL.Path); ok && !isErrorPage {
+		res := runLoader(disp.Context(), r.URL.Path, loader)
+		loaded = &res
+		page.loaderResult = loaded
+	}
+
+	disp.mountRoute(route, loaded)
+
+	if page.shareTargetData != nil {
+		disp.shareTarget(*page.shareTargetData)
+	}
+
+	if h.StreamPreRendering {
+		completed := h.streamPage(ctx, w, r, disp, &page, body)
+		h.closePreRendering(ctx, r, disp, completed)
+		return nil
+	}
+
+	completed := preRender(ctx, disp)
+	defer h.closePreRendering(ctx, r, disp, completed)
+
+	if redirectPage(w, r, disp, &page) {
+		return nil
+	}
+	page.hydratedStates = disp.states.hydratedStates()
+
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n")
+	PrintHTML(&b, h.pageHTML(&page, body))
+
+	w.Header().Set("Content-Length", strconv.Itoa(b.Len()))
+	w.Header().Set("Content-Type", "text/html")
+	writePageHeader(w, &page)
+	w.Write(b.Bytes())
+	return nil
+}
+
+// pageResponseWriter is a response writer that reports whether a response
+// has started to be written.
+type pageResponseWriter struct {
+	http.ResponseWriter
+
+	written bool
+}
+
+func (w *pageResponseWriter) WriteHeader(statusCode int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *pageResponseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *pageResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// pageRequest returns the copy of the given request that is exposed with
+// Page.Request. The copy has no body since the body of the request is owned by
+// the handler.
+func pageRequest(r *http.Request) *http.Request {
+	pr := r.Clone(r.Context())
+	pr.Body = http.NoBody
+	pr.GetBody = nil
+	return pr
+}
+
+// guardPage executes the navigation middlewares before a page is
+// pre-rendered. It reports whether the page can be pre-rendered, otherwise a
+// redirection or a forbidden status is written.
+func guardPage(w http.ResponseWriter, r *http.Request, disp *engine) bool {
+	to := *withBasePath(disp.BasePath, r.URL)
+
+	u, ok := routes.guardNav(disp.Context(), disp.BasePath, nil, &to)
+	switch {
+	case !ok:
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return false
+
+	case u != &to:
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return false
 
-	disp.Mount(content)
+	default:
+		return true
+	}
+}
+
+// redirectPage writes a redirection when one has been requested while
+// pre-rendering the given page. It reports whether the page is redirected.
+func redirectPage(w http.ResponseWriter, r *http.Request, disp *engine, page *requestPage) bool {
+	if page.redirectURL == "" {
+		return false
+	}
 
-	for len(disp.dispatches) != 0 {
-		disp.Consume()
-		disp.Wait()
+	copyPageHeader(w, page)
+	http.Redirect(w, r, hrefWithBasePath(disp.BasePath, page.redirectURL), page.redirectCode)
+	return true
+}
+
+// writePageHeader writes the headers and the status code that have been set
+// while pre-rendering the given page.
+func writePageHeader(w http.ResponseWriter, page *requestPage) {
+	copyPageHeader(w, page)
+
+	if page.statusCode != 0 {
+		w.WriteHeader(page.statusCode)
+	}
+}
+
+func copyPageHeader(w http.ResponseWriter, page *requestPage) {
+	for name, values := range page.header {
+		w.Header()[name] = values
+	}
+}
+
+// preRender executes the UI instructions and waits for the asynchronous
+// operations launched while pre-rendering a page. It returns false when the
+// given context is done before the page is fully pre-rendered.
+func preRender(ctx context.Context, disp *engine) bool {
+	for {
+		disp.consumeReady()
+		if !disp.waitContext(ctx) || ctx.Err() != nil {
+			return false
+		}
+		if len(disp.dispatches) == 0 {
+			return true
+		}
 	}
+}
+
+func (h *Handler) closePreRendering(ctx context.Context, r *http.Request, disp *engine, completed bool) {
+	if completed {
+		disp.Close()
+		return
+	}
+
+	Log(errors.New("pre-rendering page did not complete").
+		WithTag("path", r.URL.Path).
+		WithTag("reason", ctx.Err().Error()).
+		WithTag("timeout", h.PreRenderTimeout).
+		WithTag("pending-components", pendingComponents(disp)))
+
+	// The canceled asynchronous operations that are still running are not
+	// waited for, which would keep the engine alive as long as the ones that
+	// ignore the context.
+	disp.abort()
+}
+
+func pendingComponents(disp *engine) []string {
+	var names []string
+	seen := make(map[Composer]bool)
+
+	for _, src := range disp.asyncs.unfinished() {
+		c := nearestComponent(src)
+		if c == nil || seen[c] {
+			continue
+		}
+		seen[c] = true
+		names = append(names, c.name())
+	}
+
+	sort.Strings(names)
+	return names
+}
 
+func (h *Handler) pageHTML(page *requestPage, body HTMLBody) HTMLHtml {
 	icon := h.Icon.SVG
 	if icon == "" {
 		icon = h.Icon.Default
 	}
 
-	var b bytes.Buffer
-	b.WriteString("<!DOCTYPE html>\n")
-	PrintHTML(&b, h.HTML().
+	return h.HTML().
 		Lang(page.Lang()).
 		privateBody(
 			Head().Body(
@@ -815,7 +1369,8 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 
 					link := Link().
 						Rel("preconnect").
-						Href(url)
+						Href(url).
+						Attr("nonce", page.nonce)
 
 					if crossOrigin != "" {
 						link = link.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -830,10 +1385,11 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					}
 
 					link := Link().
-						Type("font/" + strings.TrimPrefix(filepath.Ext(url), ".")).
+						Type("font/"+strings.TrimPrefix(filepath.Ext(url), ".")).
 						Rel("preload").
 						Href(url).
-						As("font")
+						As("font").
+						Attr("nonce", page.nonce)
 
 					if crossOrigin != "" {
 						link = link.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -857,7 +1413,8 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 						Rel("preload").
 						Href(url).
 						As(p.As).
-						FetchPriority(p.FetchPriority)
+						FetchPriority(p.FetchPriority).
+						Attr("nonce", page.nonce)
 
 					if crossOrigin != "" {
 						link = link.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -870,12 +1427,15 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					if url == "" {
 						return nil
 					}
+					integrity, crossOrigin := h.subresourceIntegrity(url, crossOrigin)
 
 					link := Link().
 						Type("text/css").
 						Rel("preload").
 						Href(url).
-						As("style")
+						As("style").
+						Attr("nonce", page.nonce).
+						Attr("integrity", integrity)
 
 					if crossOrigin != "" {
 						link = link.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -891,17 +1451,20 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					Href(h.Icon.AppleTouch),
 				Link().
 					Rel("manifest").
-					Href(h.resolvePackagePath("/manifest.webmanifest")),
+					Href(h.resolvePackagePath(h.localizedManifestPath(page.Lang()))),
 				Range(h.Styles).Slice(func(i int) UI {
 					url, crossOrigin, _ := parseSrc(h.Styles[i])
 					if url == "" {
 						return nil
 					}
+					integrity, crossOrigin := h.subresourceIntegrity(url, crossOrigin)
 
 					link := Link().
 						Rel("stylesheet").
 						Type("text/css").
-						Href(url)
+						Href(url).
+						Attr("nonce", page.nonce).
+						Attr("integrity", integrity)
 
 					if crossOrigin != "" {
 						link = link.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -909,19 +1472,19 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 
 					return link
 				}),
-				Script().
-					Defer(true).
-					Src(h.resolvePackagePath("/wasm_exec.js")),
-				Script().
-					Defer(true).
-					Src(h.resolvePackagePath("/app.js")),
+				h.packageScript("/wasm_exec.js", page.nonce),
+				h.packageScript("/app.js", page.nonce),
 				Range(h.Scripts).Slice(func(i int) UI {
 					url, crossOrigin, loading := parseSrc(h.Scripts[i])
 					if url == "" {
 						return nil
 					}
+					integrity, crossOrigin := h.subresourceIntegrity(url, crossOrigin)
 
-					script := Script().Src(url)
+					script := Script().
+						Src(url).
+						Attr("nonce", page.nonce).
+						Attr("integrity", integrity)
 
 					if crossOrigin != "" {
 						script = script.CrossOrigin(strings.Trim(crossOrigin, "true"))
@@ -938,28 +1501,49 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 					return script
 				}),
 				Range(h.RawHeaders).Slice(func(i int) UI {
+					if page.nonce != "" {
+						return Raw(setNonce(h.RawHeaders[i], page.nonce))
+					}
 					return Raw(h.RawHeaders[i])
 				}),
+				If(page.loaderResult != nil,
+					Raw(page.loaderResult.html()),
+				),
+				If(page.shareTargetData != nil,
+					Raw(jsonScript(shareTargetDataID, page.shareTargetData.withoutFileData())),
+				),
+				If(len(page.hydratedStates) != 0,
+					Raw(jsonScript(hydratedStatesID, page.hydratedStates)),
+				),
 			),
 			body,
-		))
-
-	w.Header().Set("Content-Length", strconv.Itoa(b.Len()))
-	w.Header().Set("Content-Type", "text/html")
-	w.Write(b.Bytes())
+		)
 }
 
-func (h *Handler) serveLibrary(w http.ResponseWriter, r *http.Request, library []byte) {
-	w.Header().Set("Content-Length", strconv.Itoa(len(library)))
-	w.Header().Set("Content-Type", "text/css")
-	w.Write(library)
+// packageScript returns the deferred script element of the given package
+// resource.
+func (h *Handler) packageScript(path, nonce string) HTMLScript {
+	url := h.resolvePackagePath(path)
+	script := Script().
+		Defer(true).
+		Src(url).
+		Attr("nonce", nonce)
+
+	if integrity, crossOrigin := h.subresourceIntegrity(url, ""); integrity != "" {
+		script = script.
+			Attr("integrity", integrity).
+			CrossOrigin(crossOrigin)
+	}
+	return script
 }
 
 func (h *Handler) resolvePackagePath(path string) string {
+	path = h.fingerprint("/" + strings.Trim(path, "/"))
+
 	var b strings.Builder
 
 	b.WriteByte('/')
-	appResources := strings.Trim(h.Resources.Package(), "/")
+	appResources := strings.Trim(h.BasePath+"/"+strings.Trim(h.Resources.Package(), "/"), "/")
 	b.WriteString(appResources)
 
 	path = strings.Trim(path, "/")
@@ -971,13 +1555,52 @@ func (h *Handler) resolvePackagePath(path string) string {
 	return b.String()
 }
 
+// rootPrefix returns the URL path prefix of the pages and the package
+// resources, without trailing slash.
+func (h *Handler) rootPrefix() string {
+	return strings.TrimSuffix(h.resolvePackagePath("/"), "/")
+}
+
+// staticResourcesURL returns the location of the static resources directory,
+// prefixed with the base path when the static resources are served by the
+// handler.
+func (h *Handler) staticResourcesURL() string {
+	static := h.Resources.Static()
+	if h.BasePath == "" || isRemoteLocation(static) {
+		return static
+	}
+	if static = strings.Trim(static, "/"); static == "" {
+		return h.BasePath
+	}
+	return h.BasePath + "/" + static
+}
+
+// appWASMPath returns the location of the app.wasm file, fingerprinted when
+// enabled and prefixed with the base path when it is served by the handler.
+func (h *Handler) appWASMPath() string {
+	wasm := h.Resources.AppWASM()
+	if fingerprinted, ok := h.fingerprints["/web/app.wasm"]; ok && strings.HasSuffix(wasm, "/web/app.wasm") {
+		wasm = strings.TrimSuffix(wasm, "/web/app.wasm") + fingerprinted
+	}
+	if h.BasePath == "" || !strings.HasPrefix(wasm, "/") {
+		return wasm
+	}
+	return h.BasePath + wasm
+}
+
 func (h *Handler) resolveStaticPath(path string) string {
+	if url, _, _ := parseSrc(path); url != path {
+		return replaceSrcURL(path, url, h.resolveStaticPath(url))
+	}
+
 	if isRemoteLocation(path) || !isStaticResourcePath(path) {
 		return path
 	}
 
+	path = h.fingerprint("/" + strings.TrimPrefix(path, "/"))
+
 	var b strings.Builder
-	staticResources := strings.TrimSuffix(h.Resources.Static(), "/")
+	staticResources := strings.TrimSuffix(h.staticResourcesURL(), "/")
 	b.WriteString(staticResources)
 	path = strings.Trim(path, "/")
 	b.WriteByte('/')
@@ -1025,6 +1648,19 @@ func isStaticResourcePath(path string) bool {
 		strings.HasPrefix(path, "web/")
 }
 
+// replaceSrcURL returns the given src entry with its url replaced by the given
+// one, keeping the attributes that follow it.
+func replaceSrcURL(src, url, newURL string) string {
+	parts := strings.Split(src, " ")
+	for i, p := range parts {
+		if strings.TrimSpace(p) == url {
+			parts[i] = newURL
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func parseSrc(link string) (url, crossOrigin, loading string) {
 	for _, p := range strings.Split(link, " ") {
 		p = strings.TrimSpace(p)
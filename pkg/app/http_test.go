@@ -4,17 +4,66 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	Route("/", &preRenderTestCompo{})
+	Route("/stream", &streamTestCompo{})
+	Route("/stream-concurrent", &streamConcurrentTestCompo{})
+	Route("/timeout", &timeoutTestCompo{})
+	Route("/gone", &statusTestCompo{})
+	Route("/moved", &redirectTestCompo{})
+	Route("/request", &requestTestCompo{})
+	Route("/typed/{id:int}", &preRenderTestCompo{})
+	Layout("/layout", &layoutTestCompo{})
+	Route("/layout/page", &preRenderTestCompo{})
+	Route("/guarded/{name}", &preRenderTestCompo{})
+	Route("/loader/{name}", &loaderTestCompo{})
+	Route("/states", &statesTestCompo{})
+	Route("/panic", &panicPreRenderTestCompo{})
+	Route("/share", &shareTargetTestCompo{})
+	NamedRoute("links", "/links/{id}", &linksTestCompo{})
+	Route("/csp", &cspTestCompo{})
+
+	ErrorPage(func(err error) Composer {
+		return &errorPageTestCompo{err: err}
+	})
+
+	Loader("/loader/{name}", func(ctx Context) (any, error) {
+		if name := ctx.Param("name"); name != "error" {
+			return loaderTestData{Name: name}, nil
+		}
+		return nil, fmt.Errorf("loader failed")
+	})
+
+	UseNavMiddleware(func(ctx Context, from, to *url.URL) NavDecision {
+		switch to.Path {
+		case "/guarded/redirect", "/admin/guarded/redirect":
+			return RedirectNav("/guarded/login")
+
+		case "/guarded/cancel":
+			return CancelNav()
+
+		case "/guarded/request":
+			if ctx.Page().Request().Header.Get("Authorization") == "" {
+				return RedirectNav("login")
+			}
+		}
+		return AllowNav()
+	})
 }
 
 type preRenderTestCompo struct {
@@ -29,6 +78,197 @@ func (c *preRenderTestCompo) Render() UI {
 		)
 }
 
+type panicPreRenderTestCompo struct {
+	Compo
+}
+
+func (c *panicPreRenderTestCompo) OnPreRender(ctx Context) {
+	panic("pre-rendering panic")
+}
+
+type errorPageTestCompo struct {
+	Compo
+
+	err error
+}
+
+func (c *errorPageTestCompo) Render() UI {
+	return P().ID("error-page").Text(c.err.Error())
+}
+
+type statesTestCompo struct {
+	Compo
+}
+
+func (c *statesTestCompo) OnPreRender(ctx Context) {
+	ctx.SetState("/test/states/name", "Maxence", Hydrate)
+	ctx.SetState("/test/states/server", "secret")
+
+	ctx.Async(func() {
+		ctx.SetState("/test/states/async", 42, Hydrate)
+	})
+}
+
+func (c *statesTestCompo) Render() UI {
+	return Div().ID("states")
+}
+
+type streamTestCompo struct {
+	Compo
+}
+
+func (c *streamTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().SetTitle("Streaming")
+}
+
+func (c *streamTestCompo) Render() UI {
+	return Div().
+		ID("stream-ok").
+		Body(
+			H1().Text("static"),
+			&streamTestAsyncCompo{},
+		)
+}
+
+type streamTestAsyncCompo struct {
+	Compo
+
+	loaded bool
+}
+
+func (c *streamTestAsyncCompo) OnPreRender(ctx Context) {
+	ctx.Async(func() {
+		time.Sleep(time.Millisecond * 10)
+		ctx.Dispatch(func(ctx Context) {
+			c.loaded = true
+		})
+	})
+}
+
+func (c *streamTestAsyncCompo) Render() UI {
+	if !c.loaded {
+		return P().Text("loading")
+	}
+	return P().Text("loaded")
+}
+
+type streamConcurrentTestCompo struct {
+	Compo
+
+	loaded int
+}
+
+func (c *streamConcurrentTestCompo) OnPreRender(ctx Context) {
+	for i := 0; i < 4; i++ {
+		ctx.Async(func() {
+			time.Sleep(time.Millisecond)
+			ctx.Dispatch(func(ctx Context) {
+				c.loaded++
+			})
+		})
+	}
+}
+
+func (c *streamConcurrentTestCompo) Render() UI {
+	return P().Textf("loaded %v", c.loaded)
+}
+
+type timeoutTestCompo struct {
+	Compo
+
+	canceled bool
+}
+
+func (c *timeoutTestCompo) OnPreRender(ctx Context) {
+	ctx.Async(func() {
+		select {
+		case <-ctx.Done():
+			c.canceled = true
+
+		case <-time.After(time.Millisecond * 200):
+		}
+	})
+}
+
+func (c *timeoutTestCompo) Render() UI {
+	return P().Text("loading")
+}
+
+type statusTestCompo struct {
+	Compo
+}
+
+func (c *statusTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().SetStatusCode(http.StatusGone)
+	ctx.Page().AddHeader("Cache-Control", "no-store")
+	ctx.Page().AddHeader("X-Test", "foo")
+	ctx.Page().AddHeader("X-Test", "bar")
+}
+
+func (c *statusTestCompo) Render() UI {
+	return P().Text("gone")
+}
+
+type redirectTestCompo struct {
+	Compo
+}
+
+func (c *redirectTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().AddHeader("X-Test", "foo")
+	ctx.Page().Redirect("/", http.StatusMovedPermanently)
+}
+
+func (c *redirectTestCompo) Render() UI {
+	return P().Text("moved")
+}
+
+type requestTestContextKey struct{}
+
+type requestTestCompo struct {
+	Compo
+
+	lang     string
+	cookie   string
+	user     any
+	ctxUser  any
+	bodySize int
+}
+
+func (c *requestTestCompo) OnPreRender(ctx Context) {
+	r := ctx.Page().Request()
+	c.lang = r.Header.Get("Accept-Language")
+	if cookie, err := r.Cookie("session"); err == nil {
+		c.cookie = cookie.Value
+	}
+	c.user = r.Context().Value(requestTestContextKey{})
+	c.ctxUser = ctx.Value(requestTestContextKey{})
+	body, _ := ioutil.ReadAll(r.Body)
+	c.bodySize = len(body)
+}
+
+func (c *requestTestCompo) Render() UI {
+	return P().Text(fmt.Sprintf("%s %s %v %v %v", c.lang, c.cookie, c.user, c.ctxUser, c.bodySize))
+}
+
+type linksTestCompo struct {
+	Compo
+
+	id string
+}
+
+func (c *linksTestCompo) OnPreRender(ctx Context) {
+	c.id = ctx.Param("id")
+}
+
+func (c *linksTestCompo) Render() UI {
+	return Div().Body(
+		P().ID("links-param").Text(c.id),
+		A().ID("links-href").Href("/typed/42"),
+		A().ID("links-url-for").Href(URLFor("links", PathParam("id", 21))),
+		A().ID("links-admin").Href("/admin/users"),
+	)
+}
+
 func TestHandlerServePageWithLocalDir(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -153,6 +393,677 @@ func TestHandlerServePageWithGitHubPages(t *testing.T) {
 	t.Log(body)
 }
 
+func TestHandlerServePageWithStreaming(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{
+		Title:              "Handler testing",
+		Resources:          LocalDir(""),
+		StreamPreRendering: true,
+	}
+	h.ServeHTTP(w, r)
+
+	body := w.Body.String()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Content-Length"))
+	require.True(t, w.Flushed)
+	require.Contains(t, body, `<title>Streaming</title>`)
+	require.Contains(t, body, `<!--goapp-stream:1--><div id="stream-ok">`)
+	require.Contains(t, body, `<!--goapp-stream:2--><p>loading</p><!--/goapp-stream:2-->`)
+	require.Contains(t, body, `function goappResolveStream(id)`)
+	require.Contains(t, body, `<template data-goapp-stream="2"><p>loaded</p></template><script>goappResolveStream(2)</script>`)
+	require.NotContains(t, body, `<template data-goapp-stream="1">`)
+	require.Less(t, strings.Index(body, `<!--/goapp-stream:1-->`), strings.Index(body, `<template data-goapp-stream="2">`))
+	require.True(t, strings.HasSuffix(body, "</body>\n</html>"))
+
+	t.Log(body)
+}
+
+func TestHandlerServePageWithConcurrentStreaming(t *testing.T) {
+	defaultLogger := DefaultLogger
+	defer func() {
+		DefaultLogger = defaultLogger
+	}()
+
+	var logs []string
+	DefaultLogger = func(format string, v ...any) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	}
+
+	for i := 0; i < 20; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/stream-concurrent", nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:          LocalDir(""),
+			PreRenderTimeout:   time.Second * 5,
+			StreamPreRendering: true,
+		}
+		start := time.Now()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, time.Since(start) < time.Second)
+		require.Contains(t, w.Body.String(), `<p>loaded 4</p>`)
+		require.Empty(t, logs)
+	}
+}
+
+type cspTestCompo struct {
+	Compo
+
+	nonce  string
+	loaded bool
+}
+
+func (c *cspTestCompo) OnPreRender(ctx Context) {
+	c.nonce = ctx.Page().Nonce()
+	ctx.Async(func() {
+		ctx.Dispatch(func(ctx Context) {
+			c.loaded = true
+		})
+	})
+}
+
+func (c *cspTestCompo) Render() UI {
+	return Div().Body(
+		Style().Attr("nonce", c.nonce).Text("p{}"),
+		Script().Text("console.log(1)"),
+		P().Style("color", "red").Text("styled"),
+		If(c.loaded,
+			Script().Attr("nonce", c.nonce).Text("console.log(2)"),
+		),
+	)
+}
+
+func TestHandlerServePageWithContentSecurityPolicy(t *testing.T) {
+	serve := func(path string, stream bool) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:             LocalDir(""),
+			ContentSecurityPolicy: CSP().DefaultSrc("'self'"),
+			Scripts:               []string{"/web/hello.js"},
+			RawHeaders:            []string{`<script>console.log("raw")</script>`},
+			StreamPreRendering:    stream,
+		}
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	nonceOf := func(t *testing.T, w *httptest.ResponseRecorder) string {
+		policy := w.Header().Get("Content-Security-Policy")
+		start := strings.Index(policy, "'nonce-")
+		require.NotEqual(t, -1, start)
+		nonce := policy[start+len("'nonce-"):]
+		return nonce[:strings.Index(nonce, "'")]
+	}
+
+	t.Run("page", func(t *testing.T) {
+		w := serve("/", false)
+		body := w.Body.String()
+		nonce := nonceOf(t, w)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Header().Get("Content-Security-Policy"), "script-src 'self' 'nonce-"+nonce+"' 'wasm-unsafe-eval'")
+		require.Contains(t, body, `nonce="`+nonce+`"`)
+		require.Contains(t, body, `<script nonce="`+nonce+`">console.log("raw")</script>`)
+		require.Equal(t, 6, strings.Count(body, `nonce="`+nonce+`"`))
+		require.NotEqual(t, nonce, nonceOf(t, serve("/", false)))
+	})
+
+	t.Run("streamed page", func(t *testing.T) {
+		w := serve("/stream", true)
+		body := w.Body.String()
+		nonce := nonceOf(t, w)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<template data-goapp-stream="2"><p>loaded</p></template><script nonce="`+nonce+`">goappResolveStream(2)</script>`)
+		require.NotContains(t, body, `<script>`)
+	})
+
+	t.Run("component elements", func(t *testing.T) {
+		w := serve("/csp", false)
+		body := w.Body.String()
+		nonce := nonceOf(t, w)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<style nonce="`+nonce+`">p{}</style>`)
+		require.Contains(t, body, `<script>console.log(1)</script>`)
+		require.Contains(t, body, `<script nonce="`+nonce+`">console.log(2)</script>`)
+		require.Contains(t, body, `<p style="color:red;">styled</p>`)
+		require.Contains(t, w.Header().Get("Content-Security-Policy"), "style-src-attr 'unsafe-inline'")
+	})
+}
+
+func TestHandlerServePageWithSubresourceIntegrity(t *testing.T) {
+	close := testCreateDir(t, "web")
+	defer close()
+	testCreateFile(t, filepath.Join("web", "hello.js"), "console.log('hello')")
+	testCreateFile(t, filepath.Join("web", "hello.css"), "p{}")
+
+	h := Handler{
+		Resources:            LocalDir(""),
+		SubresourceIntegrity: true,
+		Scripts: []string{
+			"/web/hello.js",
+			"https://example.com/remote.js",
+		},
+		Styles: []string{"/web/hello.css"},
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	appJS := serve("/app.js").Body.Bytes()
+	w := serve("/")
+	body := w.Body.String()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, body, `integrity="`+integrityOf(appJS)+`"`)
+	require.Contains(t, body, `integrity="`+integrityOf([]byte(wasmExecJS()))+`"`)
+	require.Contains(t, body, `integrity="`+integrityOf([]byte(appCSS))+`"`)
+	require.Contains(t, body, `integrity="`+integrityOf([]byte("console.log('hello')"))+`"`)
+	require.Equal(t, 2, strings.Count(body, `integrity="`+integrityOf([]byte("p{}"))+`"`))
+	require.Contains(t, body, `crossorigin="anonymous"`)
+	require.Contains(t, body, `<script src="https://example.com/remote.js"></script>`)
+}
+
+func TestHandlerServeFingerprintedResources(t *testing.T) {
+	close := testCreateDir(t, "web")
+	defer close()
+	testCreateFile(t, filepath.Join("web", "hello.css"), "p{}")
+	testCreateFile(t, filepath.Join("web", "cors.css"), "div{}")
+	testCreateFile(t, filepath.Join("web", "hello.js"), "console.log('hello')")
+	testCreateFile(t, filepath.Join("web", "app.wasm"), "wasm!")
+
+	h := Handler{
+		Resources:            LocalDir(""),
+		FingerprintResources: true,
+		Styles: []string{
+			"/web/hello.css",
+			"/web/cors.css crossorigin",
+			"https://example.com/remote.css",
+		},
+		Scripts: []string{"/web/hello.js defer"},
+	}
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	appJS := serve("/app.js", nil).Body.Bytes()
+	appJSPath := fingerprintPath("/app.js", appJS)
+	appCSSPath := fingerprintPath("/app.css", []byte(appCSS))
+	helloCSSPath := fingerprintPath("/web/hello.css", []byte("p{}"))
+	corsCSSPath := fingerprintPath("/web/cors.css", []byte("div{}"))
+	helloJSPath := fingerprintPath("/web/hello.js", []byte("console.log('hello')"))
+	wasmPath := fingerprintPath("/web/app.wasm", []byte("wasm!"))
+
+	t.Run("page", func(t *testing.T) {
+		w := serve("/", nil)
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		require.Contains(t, body, `src="`+appJSPath+`"`)
+		require.Contains(t, body, `href="`+appCSSPath+`"`)
+		require.Contains(t, body, `href="`+helloCSSPath+`"`)
+		require.Contains(t, body, `href="`+corsCSSPath+`"`)
+		require.Contains(t, body, `src="`+helloJSPath+`"`)
+		require.Contains(t, body, `href="https://example.com/remote.css"`)
+		require.Contains(t, body, `href="/manifest.webmanifest"`)
+	})
+
+	t.Run("page is always rendered", func(t *testing.T) {
+		require.Empty(t, serve("/", nil).Header().Get("ETag"))
+
+		w := serve("/", http.Header{"If-None-Match": {`"` + h.Version + `"`}})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `href="`+appCSSPath+`"`)
+	})
+
+	t.Run("app.js", func(t *testing.T) {
+		require.Contains(t, string(appJS), `fetchWithProgress("`+wasmPath+`"`)
+
+		w := serve(appJSPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+		require.Equal(t, appJS, w.Body.Bytes())
+	})
+
+	t.Run("app.js not modified", func(t *testing.T) {
+		w := serve("/app.js", nil)
+		etag := w.Header().Get("ETag")
+		require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		require.Equal(t, `"`+contentHash(appJS)+`"`, etag)
+
+		w = serve("/app.js", http.Header{"If-None-Match": {etag}})
+		require.Equal(t, http.StatusNotModified, w.Code)
+		require.Empty(t, w.Body.String())
+
+		w = serve("/app.js", http.Header{
+			"If-None-Match":   {etag},
+			"Accept-Encoding": {"gzip"},
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `"`+contentHash(appJS)+`-gzip"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("static resource", func(t *testing.T) {
+		w := serve(helloCSSPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+		require.Equal(t, "p{}", w.Body.String())
+	})
+
+	t.Run("static resource not modified", func(t *testing.T) {
+		w := serve("/web/hello.css", nil)
+		etag := w.Header().Get("ETag")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotEmpty(t, etag)
+		require.NotEqual(t, etag, serve("/web/cors.css", nil).Header().Get("ETag"))
+
+		w = serve("/web/hello.css", http.Header{"If-None-Match": {`"other", W/` + etag}})
+		require.Equal(t, http.StatusNotModified, w.Code)
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("wasm", func(t *testing.T) {
+		w := serve(wasmPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+		require.Equal(t, "application/wasm", w.Header().Get("Content-Type"))
+		require.Equal(t, "wasm!", w.Body.String())
+	})
+
+	t.Run("app-worker.js", func(t *testing.T) {
+		w := serve("/app-worker.js", nil)
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		require.Contains(t, body, `"`+appJSPath+`"`)
+		require.Contains(t, body, `"`+helloCSSPath+`"`)
+		require.Contains(t, body, `"`+corsCSSPath+`"`)
+		require.Contains(t, body, `"`+helloJSPath+`"`)
+		require.Contains(t, body, `"`+wasmPath+`"`)
+	})
+}
+
+func TestMatchesETag(t *testing.T) {
+	utests := []struct {
+		scenario    string
+		ifNoneMatch string
+		etag        string
+		expected    bool
+	}{
+		{
+			scenario: "no header",
+			etag:     `"42"`,
+		},
+		{
+			scenario:    "same value",
+			ifNoneMatch: `"42"`,
+			etag:        `"42"`,
+			expected:    true,
+		},
+		{
+			scenario:    "different value",
+			ifNoneMatch: `"21"`,
+			etag:        `"42"`,
+		},
+		{
+			scenario:    "list",
+			ifNoneMatch: `"21", "42"`,
+			etag:        `"42"`,
+			expected:    true,
+		},
+		{
+			scenario:    "weak validator",
+			ifNoneMatch: `W/"42"`,
+			etag:        `"42"`,
+			expected:    true,
+		},
+		{
+			scenario:    "weak entity tag",
+			ifNoneMatch: `"42"`,
+			etag:        `W/"42"`,
+			expected:    true,
+		},
+		{
+			scenario:    "any",
+			ifNoneMatch: `*`,
+			etag:        `"42"`,
+			expected:    true,
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if u.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match", u.ifNoneMatch)
+			}
+			require.Equal(t, u.expected, matchesETag(r, u.etag))
+		})
+	}
+}
+
+func TestHandlerServePageWithPreRenderTimeout(t *testing.T) {
+	defaultLogger := DefaultLogger
+	defer func() {
+		DefaultLogger = defaultLogger
+	}()
+
+	var logs []string
+	DefaultLogger = func(format string, v ...any) {
+		logs = append(logs, fmt.Sprintf(format, v...))
+	}
+
+	t.Run("buffered", func(t *testing.T) {
+		logs = nil
+		r := httptest.NewRequest(http.MethodGet, "/timeout", nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:        LocalDir(""),
+			PreRenderTimeout: time.Millisecond * 10,
+		}
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<p>loading</p>`)
+		require.Len(t, logs, 1)
+		require.Contains(t, logs[0], "pre-rendering page did not complete")
+		require.Contains(t, logs[0], "timeoutTestCompo")
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		logs = nil
+		r := httptest.NewRequest(http.MethodGet, "/timeout", nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:          LocalDir(""),
+			PreRenderTimeout:   time.Millisecond * 10,
+			StreamPreRendering: true,
+		}
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<p>loading</p>`)
+		require.NotContains(t, body, `<template data-goapp-stream=`)
+		require.True(t, strings.HasSuffix(body, "</html>"))
+		require.Len(t, logs, 1)
+		require.Contains(t, logs[0], "timeoutTestCompo")
+	})
+
+	t.Run("request canceled", func(t *testing.T) {
+		logs = nil
+		ctx, cancel := context.WithCancel(context.Background())
+		r := httptest.NewRequest(http.MethodGet, "/timeout", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		cancel()
+
+		h := Handler{Resources: LocalDir("")}
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, logs, 1)
+		require.Contains(t, logs[0], "context canceled")
+	})
+}
+
+func TestHandlerServePageWithStatusCode(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		r := httptest.NewRequest(http.MethodGet, "/gone", nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:          LocalDir(""),
+			StreamPreRendering: stream,
+		}
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusGone, w.Code)
+		require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		require.Equal(t, []string{"foo", "bar"}, w.Header().Values("X-Test"))
+		require.Equal(t, "text/html", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), `<p>gone</p>`)
+	}
+}
+
+func TestHandlerServePageWithRedirect(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		r := httptest.NewRequest(http.MethodGet, "/moved", nil)
+		w := httptest.NewRecorder()
+
+		h := Handler{
+			Resources:          LocalDir(""),
+			StreamPreRendering: stream,
+		}
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, "/", w.Header().Get("Location"))
+		require.Equal(t, "foo", w.Header().Get("X-Test"))
+		require.NotContains(t, w.Body.String(), `<p>moved</p>`)
+	}
+}
+
+func TestHandlerServePageWithRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/request", strings.NewReader("hello"))
+	r.Header.Set("Accept-Language", "fr")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "42"})
+	w := httptest.NewRecorder()
+
+	var body []byte
+	h := &Handler{Resources: LocalDir("")}
+	middleware := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestTestContextKey{}, "maxence")
+		h.ServeHTTP(w, r.WithContext(ctx))
+		body, _ = ioutil.ReadAll(r.Body)
+	})
+	middleware.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<p>fr 42 maxence maxence 0</p>`)
+	require.Equal(t, "hello", string(body))
+}
+
+func TestHandlerServePageWithParams(t *testing.T) {
+	h := Handler{Resources: LocalDir("")}
+
+	r := httptest.NewRequest(http.MethodGet, "/typed/42", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+
+	r = httptest.NewRequest(http.MethodGet, "/typed/abc", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandlerServePageWithLayout(t *testing.T) {
+	h := Handler{Resources: LocalDir("")}
+
+	r := httptest.NewRequest(http.MethodGet, "/layout/page", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "<nav>menu</nav>")
+	require.Contains(t, w.Body.String(), `<main><div id="pre-render-ok">`)
+}
+
+func TestHandlerServePageWithNavMiddleware(t *testing.T) {
+	h := Handler{Resources: LocalDir("")}
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		w := serve("/guarded/allow", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		w := serve("/guarded/redirect", nil)
+		require.Equal(t, http.StatusFound, w.Code)
+		require.Equal(t, "/guarded/login", w.Header().Get("Location"))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		w := serve("/guarded/cancel", nil)
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.NotContains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	})
+
+	t.Run("request", func(t *testing.T) {
+		w := serve("/guarded/request", nil)
+		require.Equal(t, http.StatusFound, w.Code)
+		require.Equal(t, "/guarded/login", w.Header().Get("Location"))
+
+		w = serve("/guarded/request", http.Header{"Authorization": {"Bearer 42"}})
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestHandlerServePageWithLoader(t *testing.T) {
+	h := Handler{Resources: LocalDir("")}
+
+	t.Run("data", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/loader/maxence", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<p id="loader-data">maxence</p>`)
+		require.Contains(t, body, `<script id="goapp-loader-data" type="application/json">{"path":"/loader/maxence","data":{"Name":"maxence"}}</script>`)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/loader/error", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<p id="loader-error">`)
+		require.Contains(t, body, `"error":"loader failed"`)
+	})
+
+	t.Run("without loader", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.NotContains(t, w.Body.String(), "goapp-loader-data")
+	})
+}
+
+func TestHandlerServePageWithHydratedStates(t *testing.T) {
+	for _, streaming := range []bool{false, true} {
+		h := Handler{
+			Resources:          LocalDir(""),
+			StreamPreRendering: streaming,
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/states", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<script id="goapp-states" type="application/json">{"/test/states/async":42,"/test/states/name":"Maxence"}</script>`)
+		require.NotContains(t, body, "secret")
+	}
+}
+
+func TestHandlerServePageNotFound(t *testing.T) {
+	h := Handler{Resources: LocalDir("")}
+
+	r := httptest.NewRequest(http.MethodGet, "/not-routed", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "goapp-notfound-title")
+
+	t.Run("asset", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/favicon.ico", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.NotContains(t, w.Body.String(), "<html")
+	})
+
+	t.Run("page with extension", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/docs/page.html", nil)
+		r.Header.Set("Accept", "text/html,application/xhtml+xml")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), "goapp-notfound-title")
+	})
+}
+
+func TestHandlerServePageWithErrorPage(t *testing.T) {
+	for _, streaming := range []bool{false, true} {
+		h := Handler{
+			Resources:          LocalDir(""),
+			StreamPreRendering: streaming,
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/panic", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), `<p id="error-page">`)
+		require.Contains(t, w.Body.String(), "pre-rendering panic")
+	}
+}
+
+func TestHandlerServePageWithoutErrorPage(t *testing.T) {
+	defer func(newErrorPage func(error) Composer) {
+		routes.newErrorPage = newErrorPage
+	}(routes.newErrorPage)
+	routes.newErrorPage = nil
+
+	h := Handler{Resources: LocalDir("")}
+
+	r := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "Internal Server Error\n", w.Body.String())
+}
+
 func TestHandlerServeWasmExecJS(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/wasm_exec.js", nil)
 	w := httptest.NewRecorder()
@@ -418,6 +1329,144 @@ func TestHandlerServeManifestJSONWithGitHubPages(t *testing.T) {
 	require.Contains(t, body, `"start_url": "/go-app/"`)
 }
 
+func TestHandlerServeWithBasePath(t *testing.T) {
+	h := Handler{
+		BasePath: "/admin/",
+		Name:     "foobar",
+		Styles:   []string{"/web/hello.css"},
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("page", func(t *testing.T) {
+		w := serve("/admin/")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<div id="pre-render-ok">`)
+		require.Contains(t, body, `href="/admin/app.css"`)
+		require.Contains(t, body, `href="/admin/web/hello.css"`)
+		require.Contains(t, body, `href="/admin/manifest.webmanifest"`)
+		require.Contains(t, body, `src="/admin/app.js"`)
+		require.Contains(t, body, `<img src="/admin/web/resolve-static-resource-test.jpg">`)
+	})
+
+	t.Run("page without trailing slash", func(t *testing.T) {
+		w := serve("/admin")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	})
+
+	t.Run("page with stripped prefix", func(t *testing.T) {
+		w := serve("/layout/page")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		w := serve("/admin/layout/page")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	})
+
+	t.Run("not found route", func(t *testing.T) {
+		w := serve("/admin/unknown")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("route params and links", func(t *testing.T) {
+		w := serve("/admin/links/42")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<p id="links-param">42</p>`)
+		require.Contains(t, body, `href="/admin/typed/42"`)
+		require.Contains(t, body, `href="/admin/links/21"`)
+		require.Contains(t, body, `href="/admin/admin/users"`)
+	})
+
+	t.Run("route loader", func(t *testing.T) {
+		w := serve("/admin/loader/maxence")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `<p id="loader-data">maxence</p>`)
+		require.Contains(t, body, `{"path":"/loader/maxence","data":{"Name":"maxence"}}`)
+	})
+
+	t.Run("nav middleware redirect", func(t *testing.T) {
+		w := serve("/admin/guarded/redirect")
+		require.Equal(t, http.StatusFound, w.Code)
+		require.Equal(t, "/admin/guarded/login", w.Header().Get("Location"))
+	})
+
+	t.Run("page redirect", func(t *testing.T) {
+		w := serve("/admin/moved")
+		require.Equal(t, http.StatusMovedPermanently, w.Code)
+		require.Equal(t, "/admin/", w.Header().Get("Location"))
+	})
+
+	t.Run("app.js", func(t *testing.T) {
+		w := serve("/admin/app.js")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+		require.Contains(t, body, `"/admin/app-worker.js"`)
+		require.Contains(t, body, `fetchWithProgress("/admin/web/app.wasm"`)
+		require.Contains(t, body, `"GOAPP_STATIC_RESOURCES_URL":"/admin"`)
+		require.Contains(t, body, `"GOAPP_ROOT_PREFIX":"/admin"`)
+		require.Contains(t, body, `"GOAPP_BASE_PATH":"/admin"`)
+	})
+
+	t.Run("app-worker.js", func(t *testing.T) {
+		w := serve("/admin/app-worker.js")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `"/admin"`)
+		require.Contains(t, body, `"/admin/app.js"`)
+		require.Contains(t, body, `"/admin/web/app.wasm"`)
+		require.Contains(t, body, `"/admin/web/hello.css"`)
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		w := serve("/admin/manifest.webmanifest")
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, `"scope": "/admin/"`)
+		require.Contains(t, body, `"start_url": "/admin/"`)
+	})
+}
+
+func TestHandlerServeWithSeveralBasePaths(t *testing.T) {
+	admin := Handler{BasePath: "/admin"}
+	shop := Handler{BasePath: "/shop"}
+
+	serve := func(h *Handler, path string) string {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	serve(&admin, "/admin/links/42")
+	serve(&shop, "/shop/links/42")
+
+	body := serve(&admin, "/admin/links/42")
+	require.Contains(t, body, `<p id="links-param">42</p>`)
+	require.Contains(t, body, `href="/admin/typed/42"`)
+	require.Contains(t, body, `href="/admin/links/21"`)
+	require.NotContains(t, body, `/shop/`)
+
+	body = serve(&shop, "/shop/links/42")
+	require.Contains(t, body, `<p id="links-param">42</p>`)
+	require.Contains(t, body, `href="/shop/typed/42"`)
+	require.Contains(t, body, `href="/shop/links/21"`)
+	require.NotContains(t, body, `/admin/typed`)
+}
+
 func TestHandlerServeAppCSS(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/app.css", nil)
 	w := httptest.NewRecorder()
@@ -469,6 +1518,83 @@ func TestHandlerServeAppWasm(t *testing.T) {
 	}
 }
 
+func TestHandlerServeAppWasmPrecompressed(t *testing.T) {
+	close := testCreateDir(t, "web")
+	defer close()
+	testCreateFile(t, filepath.Join("web", "app.wasm"), "wasm!")
+	testCreateFile(t, filepath.Join("web", "app.wasm.br"), "brotli wasm!")
+	testCreateFile(t, filepath.Join("web", "app.wasm.gz"), "gzip wasm!")
+
+	h := Handler{}
+	h.init()
+
+	utests := []struct {
+		scenario         string
+		acceptEncoding   string
+		expectedEncoding string
+		expectedBody     string
+	}{
+		{
+			scenario:         "brotli",
+			acceptEncoding:   "gzip, deflate, br",
+			expectedEncoding: "br",
+			expectedBody:     "brotli wasm!",
+		},
+		{
+			scenario:         "gzip",
+			acceptEncoding:   "gzip, deflate",
+			expectedEncoding: "gzip",
+			expectedBody:     "gzip wasm!",
+		},
+		{
+			scenario:     "uncompressed",
+			expectedBody: "wasm!",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/app.wasm", nil)
+			r.Header.Set("Accept-Encoding", u.acceptEncoding)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, "application/wasm", w.Header().Get("Content-Type"))
+			require.Equal(t, u.expectedEncoding, w.Header().Get("Content-Encoding"))
+			require.Equal(t, u.expectedBody, w.Body.String())
+
+			if u.expectedEncoding != "" {
+				require.Equal(t, "5", w.Header().Get(uncompressedContentLengthHeader))
+				require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
+
+func TestHandlerServeCompressedPWAResource(t *testing.T) {
+	h := Handler{}
+
+	r := httptest.NewRequest(http.MethodGet, "/app.js", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	require.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	require.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+	require.Contains(t, string(testGunzip(t, w.Body.Bytes())), "GOAPP_VERSION")
+
+	r = httptest.NewRequest(http.MethodGet, "/app.js", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Content-Encoding"))
+	require.Contains(t, w.Body.String(), "GOAPP_VERSION")
+}
+
 func TestHandlerServeFile(t *testing.T) {
 	close := testCreateDir(t, "web")
 	defer close()
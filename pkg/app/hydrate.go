@@ -0,0 +1,145 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	jsElementNode  = 1
+	jsTemplateName = "TEMPLATE"
+)
+
+// hydrationCursor iterates over the child nodes of a DOM element that has been
+// server-side pre-rendered, in order to link them to the UI elements that
+// describe them.
+type hydrationCursor struct {
+	nodes   []Value
+	adopted []bool
+	keep    func(Value) bool
+	index   int
+	last    int
+}
+
+func newHydrationCursor(jsParent Value, keep func(Value) bool) *hydrationCursor {
+	if keep == nil {
+		keep = func(Value) bool { return false }
+	}
+
+	childNodes := jsParent.Get("childNodes")
+	count := childNodes.Length()
+
+	c := &hydrationCursor{
+		nodes:   make([]Value, 0, count),
+		adopted: make([]bool, count),
+		keep:    keep,
+		last:    -1,
+	}
+	for i := 0; i < count; i++ {
+		c.nodes = append(c.nodes, childNodes.Index(i))
+	}
+	return c
+}
+
+// peek returns the next element node without moving the cursor.
+func (c *hydrationCursor) peek() (Value, bool) {
+	for i := c.index; i < len(c.nodes); i++ {
+		if n := c.nodes[i]; c.isCandidate(n) {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// next moves the cursor to the next element node and returns it.
+func (c *hydrationCursor) next() (Value, bool) {
+	for c.index < len(c.nodes) {
+		i := c.index
+		n := c.nodes[i]
+		c.index++
+
+		if c.isCandidate(n) {
+			c.last = i
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// adopt marks the element node returned by the last call to next as being
+// linked to a UI element. Adopted nodes stay in the DOM.
+func (c *hydrationCursor) adopt() {
+	if c.last >= 0 {
+		c.adopted[c.last] = true
+	}
+}
+
+func (c *hydrationCursor) isCandidate(n Value) bool {
+	return n.Get("nodeType").Int() == jsElementNode && !c.keep(n)
+}
+
+// skipRemaining moves the cursor past the remaining nodes, including them in
+// the range that is cleaned up by finish.
+func (c *hydrationCursor) skipRemaining() {
+	c.index = len(c.nodes)
+}
+
+// finish removes the nodes that have been walked by the cursor without being
+// adopted from the given DOM element and inserts the ones from the given
+// children that are not yet in the DOM, preserving the children order. Nodes
+// located after the walked range are left untouched.
+func (c *hydrationCursor) finish(jsParent Value, children []UI) {
+	for i, n := range c.nodes[:c.index] {
+		if c.adopted[i] || (n.Get("nodeType").Int() == jsElementNode && c.keep(n)) {
+			continue
+		}
+		jsParent.removeChild(n)
+	}
+
+	var nodes []Value
+	for _, child := range children {
+		forEachJSNode(child, func(n Value) {
+			nodes = append(nodes, n)
+		})
+	}
+
+	var ref Wrapper
+	for i := len(nodes) - 1; i >= 0; i-- {
+		n := nodes[i]
+		if !n.Get("parentNode").Truthy() {
+			jsParent.insertBefore(n, ref)
+		}
+		ref = n
+	}
+}
+
+func hydrate(d Dispatcher, n UI, c *hydrationCursor) error {
+	n.setSelf(n)
+	return n.hydrate(d, c)
+}
+
+func hydrateChildren(d Dispatcher, parent UI, jsParent Value, children []UI) error {
+	cursor := newHydrationCursor(jsParent, nil)
+
+	for i, c := range children {
+		if err := hydrate(d, c, cursor); err != nil {
+			return errors.New("hydrating child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				Wrap(err)
+		}
+		c.setParent(parent)
+	}
+
+	// The content of a hydrated element is entirely pre-rendered, so the
+	// nodes that are not matched by any child are stale.
+	cursor.skipRemaining()
+	cursor.finish(jsParent, children)
+	return nil
+}
+
+func isMatchingJSElement(n Value, tag string) bool {
+	return strings.EqualFold(n.Get("tagName").String(), tag)
+}
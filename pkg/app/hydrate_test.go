@@ -0,0 +1,96 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHydrate(t *testing.T) {
+	e := engine{Hydrate: true}
+	e.init()
+	defer e.Close()
+
+	compo := &hello{}
+	e.Mount(Div().Body(
+		compo,
+		Frag(
+			Span().Text("foo"),
+			Raw("<p>bar</p>"),
+		),
+		Portal("portal-target", Text("portal")),
+	))
+	e.Consume()
+
+	root := e.Body.getChildren()[0]
+	require.True(t, root.Mounted())
+	require.True(t, compo.Mounted())
+	require.Equal(t, root, compo.getParent())
+	require.False(t, e.isFirstMount)
+
+	for _, c := range root.getChildren() {
+		require.True(t, c.Mounted())
+		require.Equal(t, root, c.getParent())
+	}
+
+	e.Mount(Div().Body(
+		compo,
+		Frag(Span().Text("bar")),
+	))
+	e.Consume()
+	require.Equal(t, root, e.Body.getChildren()[0])
+	require.Len(t, root.getChildren(), 2)
+}
+
+func TestHydrateSelector(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	cursor := newHydrationCursor(Window(), nil)
+	require.Error(t, hydrate(d, Range([]int{}).Slice(nil), cursor))
+	require.Error(t, hydrate(d, If(true, nil), cursor))
+}
+
+func TestHydrationCursorEmpty(t *testing.T) {
+	cursor := newHydrationCursor(Window(), nil)
+
+	_, ok := cursor.peek()
+	require.False(t, ok)
+
+	_, ok = cursor.next()
+	require.False(t, ok)
+
+	cursor.adopt()
+	cursor.finish(Window(), nil)
+}
+
+func TestHydratePreservesNodes(t *testing.T) {
+	testSkipNonWasm(t)
+
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	container, err := Window().createElement("div", "")
+	require.NoError(t, err)
+	container.setInnerHTML(`<div><span>foo</span><p>bar</p><b>stale</b></div><i class="extension"></i>`)
+
+	jsDiv := container.firstChild()
+	jsSpan := jsDiv.firstChild()
+	jsRaw := jsSpan.Get("nextSibling")
+	jsExtension := jsDiv.Get("nextSibling")
+
+	div := Div().Body(
+		Span().Text("foo"),
+		Raw("<p>baz</p>"),
+	)
+	cursor := newHydrationCursor(container, nil)
+	require.NoError(t, hydrate(d, div, cursor))
+	cursor.finish(container, []UI{div})
+
+	require.True(t, jsDiv.Equal(div.JSValue()))
+	require.True(t, jsSpan.Equal(div.getChildren()[0].JSValue()))
+	require.False(t, jsRaw.Equal(div.getChildren()[1].JSValue()))
+	require.Equal(t, "<p>baz</p>", div.getChildren()[1].JSValue().Get("outerHTML").String())
+	require.Equal(t, 2, jsDiv.Get("childNodes").Length())
+	require.True(t, jsExtension.Equal(jsDiv.Get("nextSibling")))
+}
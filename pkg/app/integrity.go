@@ -0,0 +1,59 @@
+package app
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"io"
+	"strings"
+)
+
+// integrityOf returns the Subresource Integrity metadata of the given content,
+// which is its base64 encoded SHA-384 digest.
+func integrityOf(b []byte) string {
+	sum := sha512.Sum384(b)
+	return "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// subresourceIntegrity returns the integrity attribute value of the given
+// script or style URL, along with the crossorigin attribute value to use with
+// it. The integrity is empty when it has not been computed.
+func (h *Handler) subresourceIntegrity(url, crossOrigin string) (string, string) {
+	integrity := h.integrities[url]
+	if integrity != "" && crossOrigin == "" {
+		crossOrigin = "anonymous"
+	}
+	return integrity, crossOrigin
+}
+
+// readLocalStaticResource returns the content of the static resource located at
+// the given URL when it is served from a local directory. It returns nil when
+// the resource is not a local static resource.
+func (h *Handler) readLocalStaticResource(url string) ([]byte, error) {
+	static := strings.TrimSuffix(h.staticResourcesURL(), "/")
+	if isRemoteLocation(static) || !strings.HasPrefix(url, static+"/web/") {
+		return nil, nil
+	}
+
+	path := strings.TrimPrefix(url, static)
+	if original, ok := h.fingerprintedPaths[path]; ok {
+		path = original
+	}
+	return h.readLocalFile(path)
+}
+
+// readLocalFile returns the content of the file located at the given path in
+// the local directory of the resource provider. It returns nil when the
+// resource provider does not serve resources from a local directory.
+func (h *Handler) readLocalFile(path string) ([]byte, error) {
+	dir, ok := h.Resources.(localDir)
+	if !ok {
+		return nil, nil
+	}
+
+	f, err := dir.dir.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
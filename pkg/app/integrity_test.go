@@ -0,0 +1,30 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrityOf(t *testing.T) {
+	require.Equal(t, "sha384-OLBgp1GsljhM2TJ+sbHjaiH9txEUvgdDTAzHv2P24donTt6/529l+9Ua0vFImLlb", integrityOf(nil))
+	require.NotEqual(t, integrityOf([]byte("a")), integrityOf([]byte("b")))
+}
+
+func TestHandlerSubresourceIntegrity(t *testing.T) {
+	h := Handler{
+		integrities: map[string]string{"/app.js": "sha384-42"},
+	}
+
+	integrity, crossOrigin := h.subresourceIntegrity("/app.js", "")
+	require.Equal(t, "sha384-42", integrity)
+	require.Equal(t, "anonymous", crossOrigin)
+
+	integrity, crossOrigin = h.subresourceIntegrity("/app.js", "use-credentials")
+	require.Equal(t, "sha384-42", integrity)
+	require.Equal(t, "use-credentials", crossOrigin)
+
+	integrity, crossOrigin = h.subresourceIntegrity("/unknown.js", "")
+	require.Empty(t, integrity)
+	require.Empty(t, crossOrigin)
+}
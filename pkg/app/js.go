@@ -132,6 +132,7 @@ type Value interface {
 	firstChild() Value
 	appendChild(c Wrapper)
 	replaceChild(new, old Wrapper)
+	insertBefore(new, ref Wrapper)
 	removeChild(c Wrapper)
 	firstElementChild() Value
 	addEventListener(event string, fn Func)
@@ -107,6 +107,14 @@ func (v value) replaceChild(new, old Wrapper) {
 	v.Call("replaceChild", new, old)
 }
 
+func (v value) insertBefore(new, ref Wrapper) {
+	if ref == nil {
+		v.appendChild(new)
+		return
+	}
+	v.Call("insertBefore", new, ref)
+}
+
 func (v value) removeChild(c Wrapper) {
 	v.Call("removeChild", c)
 }
@@ -297,12 +305,12 @@ func (w *browserWindow) createTextNode(v string) Value {
 }
 
 func (w *browserWindow) addHistory(u *url.URL) {
-	w.Get("history").Call("pushState", nil, "", u.String())
+	w.Get("history").Call("pushState", newHistoryEntry(), "", toBrowserURL(u).String())
 	lastURLVisited = u
 }
 
 func (w *browserWindow) replaceHistory(u *url.URL) {
-	w.Get("history").Call("replaceState", nil, "", u.String())
+	w.Get("history").Call("replaceState", historyEntryState(), "", toBrowserURL(u).String())
 	lastURLVisited = u
 }
 
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	loaderDataID = "goapp-loader-data"
+)
+
+var (
+	// The loader result written in the server-side pre-rendered page. It is
+	// reused by the first navigation instead of executing the loader again.
+	preloadedLoaderResult *loaderResult
+)
+
+// LoaderFunc is a function that loads the data of a route before its component
+// is mounted. The returned value must be encodable to JSON.
+type LoaderFunc func(ctx Context) (any, error)
+
+// Loader attaches the given function to the route registered with the given
+// path, or pattern for routes registered with RouteWithRegexp.
+//
+// The function is executed before the routed component is mounted:
+//   - On the server, before the page is pre-rendered. The result is written
+//     in the page and reused on the client when the app is loaded.
+//   - On the client, when the page is navigated to. The routed component is
+//     mounted once the function returned.
+//
+// The result is retrieved from the routed component with Context.LoaderData.
+//
+// Eg:
+//
+//	app.Route("/users/{id:int}", &user{})
+//	app.Loader("/users/{id:int}", func(ctx app.Context) (any, error) {
+//		id, _ := ctx.ParamInt("id")
+//		return getUser(ctx, id)
+//	})
+//
+//	func (u *user) OnMount(ctx app.Context) {
+//		ctx.LoaderData(&u.data)
+//	}
+func Loader(path string, fn LoaderFunc) {
+	routes.loader(path, fn)
+}
+
+// loaderResult represents the JSON encoded result of a route loader.
+type loaderResult struct {
+	Path  string          `json:"path"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+func runLoader(ctx Context, path string, fn LoaderFunc) loaderResult {
+	res := loaderResult{Path: path}
+
+	v, err := fn(ctx)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		res.Error = errors.New("encoding loader data failed").Wrap(err).Error()
+		return res
+	}
+	res.Data = b
+	return res
+}
+
+func (r *loaderResult) decode(v any) error {
+	if r == nil {
+		return errors.New("getting loader data failed").
+			WithTag("reason", "current route does not have a loader")
+	}
+
+	if r.Error != "" {
+		return errors.New("loading route data failed").
+			WithTag("path", r.Path).
+			WithTag("reason", r.Error)
+	}
+
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return errors.New("decoding loader data failed").
+			WithTag("path", r.Path).
+			Wrap(err)
+	}
+	return nil
+}
+
+// html returns the script element that contains the encoded result, in order
+// to be read by the client.
+func (r *loaderResult) html() string {
+	if r == nil {
+		return ""
+	}
+	return jsonScript(loaderDataID, r)
+}
+
+func readPreloadedLoaderResult() *loaderResult {
+	var res loaderResult
+	if !readJSONScript(loaderDataID, &res) {
+		return nil
+	}
+	return &res
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type loaderTestData struct {
+	Name string
+}
+
+type loaderTestCompo struct {
+	Compo
+
+	data loaderTestData
+	err  error
+}
+
+func (c *loaderTestCompo) OnMount(ctx Context) {
+	c.err = ctx.LoaderData(&c.data)
+}
+
+func (c *loaderTestCompo) OnPreRender(ctx Context) {
+	c.err = ctx.LoaderData(&c.data)
+}
+
+func (c *loaderTestCompo) Render() UI {
+	if c.err != nil {
+		return P().ID("loader-error").Text(c.err.Error())
+	}
+	return P().ID("loader-data").Text(c.data.Name)
+}
+
+func TestRunLoader(t *testing.T) {
+	t.Run("data", func(t *testing.T) {
+		res := runLoader(nil, "/users/42", func(Context) (any, error) {
+			return loaderTestData{Name: "Maxence"}, nil
+		})
+		require.Equal(t, "/users/42", res.Path)
+		require.Empty(t, res.Error)
+
+		var data loaderTestData
+		require.NoError(t, res.decode(&data))
+		require.Equal(t, "Maxence", data.Name)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		res := runLoader(nil, "/users/42", func(Context) (any, error) {
+			return nil, errors.New("user not found")
+		})
+		require.Equal(t, "user not found", res.Error)
+
+		var data loaderTestData
+		require.Error(t, res.decode(&data))
+	})
+
+	t.Run("encoding error", func(t *testing.T) {
+		res := runLoader(nil, "/users/42", func(Context) (any, error) {
+			return func() {}, nil
+		})
+		require.NotEmpty(t, res.Error)
+	})
+}
+
+func TestLoaderResultDecodeNil(t *testing.T) {
+	var res *loaderResult
+	var data loaderTestData
+	require.Error(t, res.decode(&data))
+	require.Empty(t, res.html())
+}
+
+func TestLoaderResultHTML(t *testing.T) {
+	res := runLoader(nil, "/", func(Context) (any, error) {
+		return loaderTestData{Name: "</script><script>alert(1)"}, nil
+	})
+
+	html := res.html()
+	require.Equal(t, `<script id="goapp-loader-data" type="application/json">`+
+		`{"path":"/","data":{"Name":"\u003c/script\u003e\u003cscript\u003ealert(1)"}}`+
+		`</script>`, html)
+}
+
+func TestRouterRouteLoader(t *testing.T) {
+	r := makeRouter()
+	r.route("/", newZeroComponentFunc(&routeCompo{}))
+	r.route("/hello", newZeroComponentFunc(&routeCompo{}))
+	r.routeWithParams("/users/{id:int}", newZeroComponentFunc(&routeCompo{}))
+	r.routeWithRegexp("^/posts/.*", newZeroComponentFunc(&routeCompo{}))
+
+	loader := func(Context) (any, error) { return nil, nil }
+	r.loader("/hello", loader)
+	r.loader("/users/{id:int}", loader)
+	r.loader("^/posts/.*", loader)
+	r.loader("/unrouted", loader)
+
+	for _, path := range []string{"/hello", "/users/42", "/posts/hello"} {
+		_, ok := r.routeLoader(path)
+		require.True(t, ok, path)
+	}
+
+	for _, path := range []string{"/", "/users/abc", "/unrouted"} {
+		_, ok := r.routeLoader(path)
+		require.False(t, ok, path)
+	}
+}
+
+func TestEngineMountRouteWithLoaderResult(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	loaded := runLoader(nil, "/", func(Context) (any, error) {
+		return loaderTestData{Name: "Maxence"}, nil
+	})
+
+	compo := &loaderTestCompo{}
+	e.mountRoute([]Composer{compo}, &loaded)
+	e.Consume()
+	require.NoError(t, compo.err)
+	require.Equal(t, "Maxence", compo.data.Name)
+
+	compo = &loaderTestCompo{}
+	e.mountRoute([]Composer{&hello{}}, nil)
+	e.mountRoute([]Composer{compo}, nil)
+	e.Consume()
+	require.Error(t, compo.err)
+}
@@ -0,0 +1,968 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	shareTargetDataID = "goapp-share-target-data"
+
+	// The maximum number of bytes of a multipart share target request that
+	// are stored in memory. The remaining parts are stored in temporary files.
+	shareTargetMaxMemory = 8 << 20
+
+	// The maximum number of bytes of a share target request body. Larger
+	// requests are not read.
+	shareTargetMaxBodySize = 32 << 20
+
+	// The maximum size in bytes of a shared file whose content is read. The
+	// content of larger files is not read.
+	shareTargetMaxFileSize = 8 << 20
+)
+
+// ManifestIcon describes an icon of a manifest shortcut or file handler.
+type ManifestIcon struct {
+	// The path or url of the image. Path is relative to the root directory.
+	Src string `json:"src"`
+
+	// The sizes of the image, eg: "192x192" or "any".
+	Sizes string `json:"sizes,omitempty"`
+
+	// The media type of the image, eg: "image/png".
+	Type string `json:"type,omitempty"`
+
+	// The purpose of the image, eg: "any", "maskable" or "monochrome".
+	Purpose string `json:"purpose,omitempty"`
+}
+
+// ManifestShortcut describes an app shortcut that is displayed by the
+// operating system, such as in the context menu of the app icon.
+type ManifestShortcut struct {
+	// The name of the shortcut as it is displayed to the user.
+	Name string `json:"name"`
+
+	// The name of the shortcut displayed to the user when there is not enough
+	// space to display Name.
+	ShortName string `json:"short_name,omitempty"`
+
+	// The description of the shortcut.
+	Description string `json:"description,omitempty"`
+
+	// The route path that is opened by the shortcut, eg: "/compose".
+	URL string `json:"url"`
+
+	// The icons of the shortcut.
+	Icons []ManifestIcon `json:"icons,omitempty"`
+}
+
+// ManifestScreenshot describes a screenshot of the app that is displayed by
+// app stores and install dialogs.
+type ManifestScreenshot struct {
+	// The path or url of the image. Path is relative to the root directory.
+	Src string `json:"src"`
+
+	// The sizes of the image, eg: "1280x720".
+	Sizes string `json:"sizes,omitempty"`
+
+	// The media type of the image, eg: "image/png".
+	Type string `json:"type,omitempty"`
+
+	// The form factor the screenshot is made for: "narrow" or "wide".
+	FormFactor string `json:"form_factor,omitempty"`
+
+	// The accessible description of the screenshot.
+	Label string `json:"label,omitempty"`
+}
+
+// ShareTarget describes how the installed app receives the data that is
+// shared from other apps.
+//
+// The data is sent to the route defined by Action. It is decoded when the
+// page is served by the Handler and the components that implement the
+// ShareTargetHandler interface are notified with it, on the server while the
+// page is pre-rendered and on the client once the app is loaded. The content
+// of shared files is only available on the server: the client receives their
+// name, type and size. Requests larger than 32MB are not read.
+//
+// Eg:
+//
+//	app.Handler{
+//		ShareTarget: &app.ShareTarget{
+//			Action: "/share",
+//			Params: app.ShareTargetParams{
+//				Title: "title",
+//				Text:  "text",
+//				URL:   "url",
+//			},
+//		},
+//	}
+type ShareTarget struct {
+	// The route path that receives the shared data, eg: "/share".
+	Action string `json:"action"`
+
+	// The HTTP method used to send the shared data: "GET" or "POST".
+	//
+	// Default: "POST" when Params.Files is set, otherwise "GET".
+	Method string `json:"method,omitempty"`
+
+	// The encoding of the shared data when Method is "POST":
+	// "application/x-www-form-urlencoded" or "multipart/form-data".
+	//
+	// Default: "multipart/form-data" when Params.Files is set, otherwise
+	// "application/x-www-form-urlencoded".
+	Enctype string `json:"enctype,omitempty"`
+
+	// The names of the parameters that contain the shared data.
+	Params ShareTargetParams `json:"params"`
+}
+
+// ShareTargetParams describes the names of the parameters that contain the data
+// shared with the app.
+type ShareTargetParams struct {
+	// The name of the parameter that contains the shared title.
+	Title string `json:"title,omitempty"`
+
+	// The name of the parameter that contains the shared text.
+	Text string `json:"text,omitempty"`
+
+	// The name of the parameter that contains the shared URL.
+	URL string `json:"url,omitempty"`
+
+	// The parameters that contain the shared files.
+	Files []ShareTargetFiles `json:"files,omitempty"`
+}
+
+// ShareTargetFiles describes a parameter that contains shared files.
+type ShareTargetFiles struct {
+	// The name of the parameter.
+	Name string `json:"name"`
+
+	// The media types or file extensions of the accepted files, eg:
+	// "image/*" or ".png".
+	Accept []string `json:"accept"`
+}
+
+// ShareTargetData represents the data that is shared with the app.
+type ShareTargetData struct {
+	// The shared title.
+	Title string `json:"title,omitempty"`
+
+	// The shared text.
+	Text string `json:"text,omitempty"`
+
+	// The shared URL.
+	URL string `json:"url,omitempty"`
+
+	// The shared files.
+	Files []SharedFile `json:"files,omitempty"`
+}
+
+// SharedFile represents a file that is shared with the app or that the app is
+// launched with.
+type SharedFile struct {
+	// The name of the file.
+	Name string `json:"name"`
+
+	// The media type of the file.
+	Type string `json:"type,omitempty"`
+
+	// The size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// The file content. It is empty when the file is shared with the app and
+	// read on the client, or when the shared file is larger than 8MB.
+	Data []byte `json:"data,omitempty"`
+}
+
+// FileHandler describes the files that the installed app can be launched with
+// from the operating system.
+//
+// Launched files are read once the app is loaded on the client and the
+// components that implement the FileLaunchHandler interface are notified with
+// them.
+//
+// Eg:
+//
+//	app.Handler{
+//		FileHandlers: []app.FileHandler{
+//			{
+//				Action: "/open",
+//				Accept: map[string][]string{
+//					"text/markdown": {".md", ".markdown"},
+//				},
+//			},
+//		},
+//	}
+type FileHandler struct {
+	// The route path that is opened when the app is launched with a file, eg:
+	// "/open".
+	Action string `json:"action"`
+
+	// The accepted media types, associated with their file extensions.
+	Accept map[string][]string `json:"accept"`
+
+	// The icons that represent the handled files.
+	Icons []ManifestIcon `json:"icons,omitempty"`
+
+	// How the app is launched when several files are opened at once:
+	// "single-client" or "multiple-clients".
+	//
+	// Default: "single-client".
+	LaunchType string `json:"launch_type,omitempty"`
+}
+
+// ProtocolHandler describes a URL scheme that is handled by the installed app.
+//
+// Eg:
+//
+//	app.Handler{
+//		ProtocolHandlers: []app.ProtocolHandler{
+//			{
+//				Protocol: "web+music",
+//				URL:      "/play?track=%s",
+//			},
+//		},
+//	}
+type ProtocolHandler struct {
+	// The handled scheme. It is either a safelisted scheme, such as "mailto",
+	// or a custom one that starts with "web+", such as "web+music".
+	Protocol string `json:"protocol"`
+
+	// The route path that handles the launched URL. It must contain "%s",
+	// which is replaced by the escaped launched URL.
+	URL string `json:"url"`
+}
+
+// LocalizedManifest describes the web app manifest fields that are
+// translated for a language. Empty fields fall back to the ones defined in the
+// Handler.
+type LocalizedManifest struct {
+	// The name of the web application.
+	Name string
+
+	// The short name of the web application.
+	ShortName string
+
+	// The description of the web application.
+	Description string
+
+	// The app shortcuts.
+	Shortcuts []ManifestShortcut
+
+	// The app screenshots.
+	Screenshots []ManifestScreenshot
+}
+
+// manifest represents the content of a web app manifest.
+type manifest struct {
+	ID               string               `json:"id,omitempty"`
+	Lang             string               `json:"lang,omitempty"`
+	ShortName        string               `json:"short_name"`
+	Name             string               `json:"name"`
+	Description      string               `json:"description"`
+	Icons            []ManifestIcon       `json:"icons"`
+	Scope            string               `json:"scope"`
+	StartURL         string               `json:"start_url"`
+	BackgroundColor  string               `json:"background_color"`
+	ThemeColor       string               `json:"theme_color"`
+	Display          string               `json:"display"`
+	DisplayOverride  []string             `json:"display_override,omitempty"`
+	Categories       []string             `json:"categories,omitempty"`
+	Shortcuts        []ManifestShortcut   `json:"shortcuts,omitempty"`
+	Screenshots      []ManifestScreenshot `json:"screenshots,omitempty"`
+	ShareTarget      *ShareTarget         `json:"share_target,omitempty"`
+	FileHandlers     []FileHandler        `json:"file_handlers,omitempty"`
+	ProtocolHandlers []ProtocolHandler    `json:"protocol_handlers,omitempty"`
+}
+
+var (
+	displayModes = []string{
+		"fullscreen",
+		"standalone",
+		"minimal-ui",
+		"browser",
+		"window-controls-overlay",
+		"tabbed",
+	}
+
+	// The schemes that can be handled without the "web+" prefix. See
+	// https://html.spec.whatwg.org/multipage/system-state.html#safelisted-scheme.
+	safelistedSchemes = []string{
+		"bitcoin",
+		"cabal",
+		"dat",
+		"did",
+		"doi",
+		"dweb",
+		"ethereum",
+		"ftp",
+		"geo",
+		"hyper",
+		"im",
+		"ipfs",
+		"ipns",
+		"irc",
+		"ircs",
+		"magnet",
+		"mailto",
+		"matrix",
+		"mms",
+		"news",
+		"nntp",
+		"openpgp4fpr",
+		"sftp",
+		"sip",
+		"sms",
+		"smsto",
+		"ssb",
+		"ssh",
+		"tel",
+		"urn",
+		"webcal",
+		"wtai",
+		"xmpp",
+	}
+
+	customSchemeRegexp = regexp.MustCompile(`^web\+[a-z]+$`)
+	languageTagRegexp  = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{1,8})*$`)
+)
+
+func (h *Handler) initManifest() {
+	if err := h.validateManifest(); err != nil {
+		panic(errors.New("initializing manifest.webmanifest failed").Wrap(err))
+	}
+
+	if h.ShareTarget != nil {
+		// The share target is copied in order to not modify the one that is
+		// given by the caller.
+		t := *h.ShareTarget
+		t.Method = strings.ToUpper(t.Method)
+		if t.Method == "" && len(t.Params.Files) != 0 {
+			t.Method = http.MethodPost
+		} else if t.Method == "" {
+			t.Method = http.MethodGet
+		}
+
+		if t.Enctype == "" && t.Method == http.MethodPost && len(t.Params.Files) != 0 {
+			t.Enctype = "multipart/form-data"
+		} else if t.Enctype == "" && t.Method == http.MethodPost {
+			t.Enctype = "application/x-www-form-urlencoded"
+		}
+		h.ShareTarget = &t
+	}
+}
+
+func (h *Handler) validateManifest() error {
+	if h.ManifestID != "" {
+		u, err := url.Parse(h.ManifestID)
+		if err != nil {
+			return errors.New("parsing manifest id failed").
+				WithTag("id", h.ManifestID).
+				Wrap(err)
+		}
+		if u.Scheme != "" || u.Host != "" {
+			return errors.New("invalid manifest id").
+				WithTag("id", h.ManifestID).
+				WithTag("reason", "id is not relative to the app origin")
+		}
+	}
+
+	for _, d := range h.DisplayOverride {
+		if !containsString(displayModes, d) {
+			return errors.New("invalid display override").
+				WithTag("display", d).
+				WithTag("reason", "unknown display mode")
+		}
+	}
+
+	for i, c := range h.Categories {
+		if strings.TrimSpace(c) == "" {
+			return errors.New("invalid category").
+				WithTag("index", i).
+				WithTag("reason", "category is empty")
+		}
+	}
+
+	if err := validateManifestShortcuts(h.Shortcuts); err != nil {
+		return err
+	}
+	if err := validateManifestScreenshots(h.Screenshots); err != nil {
+		return err
+	}
+
+	if h.ShareTarget != nil {
+		if err := validateShareTarget(*h.ShareTarget); err != nil {
+			return err
+		}
+	}
+
+	for i, fh := range h.FileHandlers {
+		if err := validateFileHandler(fh); err != nil {
+			return errors.New("invalid file handler").
+				WithTag("index", i).
+				Wrap(err)
+		}
+	}
+
+	for i, ph := range h.ProtocolHandlers {
+		if err := validateProtocolHandler(ph); err != nil {
+			return errors.New("invalid protocol handler").
+				WithTag("index", i).
+				Wrap(err)
+		}
+	}
+
+	for lang, m := range h.LocalizedManifests {
+		if !languageTagRegexp.MatchString(lang) {
+			return errors.New("invalid localized manifest").
+				WithTag("lang", lang).
+				WithTag("reason", "lang is not a language tag")
+		}
+
+		if err := validateManifestShortcuts(m.Shortcuts); err != nil {
+			return errors.New("invalid localized manifest").
+				WithTag("lang", lang).
+				Wrap(err)
+		}
+		if err := validateManifestScreenshots(m.Screenshots); err != nil {
+			return errors.New("invalid localized manifest").
+				WithTag("lang", lang).
+				Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func validateManifestShortcuts(shortcuts []ManifestShortcut) error {
+	for i, s := range shortcuts {
+		switch {
+		case s.Name == "":
+			return errors.New("invalid shortcut").
+				WithTag("index", i).
+				WithTag("reason", "name is empty")
+
+		case !strings.HasPrefix(s.URL, "/"):
+			return errors.New("invalid shortcut").
+				WithTag("index", i).
+				WithTag("url", s.URL).
+				WithTag("reason", "url is not a route path")
+		}
+
+		if err := validateManifestIcons(s.Icons); err != nil {
+			return errors.New("invalid shortcut").
+				WithTag("index", i).
+				Wrap(err)
+		}
+	}
+	return nil
+}
+
+func validateManifestScreenshots(screenshots []ManifestScreenshot) error {
+	for i, s := range screenshots {
+		switch {
+		case s.Src == "":
+			return errors.New("invalid screenshot").
+				WithTag("index", i).
+				WithTag("reason", "src is empty")
+
+		case s.FormFactor != "" && s.FormFactor != "narrow" && s.FormFactor != "wide":
+			return errors.New("invalid screenshot").
+				WithTag("index", i).
+				WithTag("form-factor", s.FormFactor).
+				WithTag("reason", "form factor is neither narrow or wide")
+		}
+	}
+	return nil
+}
+
+func validateManifestIcons(icons []ManifestIcon) error {
+	for i, icon := range icons {
+		if icon.Src == "" {
+			return errors.New("invalid icon").
+				WithTag("index", i).
+				WithTag("reason", "src is empty")
+		}
+	}
+	return nil
+}
+
+func validateShareTarget(t ShareTarget) error {
+	method := strings.ToUpper(t.Method)
+	hasFiles := len(t.Params.Files) != 0
+
+	switch {
+	case !strings.HasPrefix(t.Action, "/"):
+		return errors.New("invalid share target").
+			WithTag("action", t.Action).
+			WithTag("reason", "action is not a route path")
+
+	case method != "" && method != http.MethodGet && method != http.MethodPost:
+		return errors.New("invalid share target").
+			WithTag("method", t.Method).
+			WithTag("reason", "method is neither GET or POST")
+
+	case t.Params.Title == "" && t.Params.Text == "" && t.Params.URL == "" && !hasFiles:
+		return errors.New("invalid share target").
+			WithTag("reason", "params are empty")
+
+	case hasFiles && method == http.MethodGet:
+		return errors.New("invalid share target").
+			WithTag("reason", "files are not shared with the GET method")
+
+	case hasFiles && t.Enctype != "" && t.Enctype != "multipart/form-data":
+		return errors.New("invalid share target").
+			WithTag("enctype", t.Enctype).
+			WithTag("reason", "files are only shared with the multipart/form-data encoding")
+
+	case method == http.MethodGet && t.Enctype != "" && t.Enctype != "application/x-www-form-urlencoded":
+		return errors.New("invalid share target").
+			WithTag("enctype", t.Enctype).
+			WithTag("reason", "GET method only supports the application/x-www-form-urlencoded encoding")
+
+	case t.Enctype != "" && t.Enctype != "application/x-www-form-urlencoded" && t.Enctype != "multipart/form-data":
+		return errors.New("invalid share target").
+			WithTag("enctype", t.Enctype).
+			WithTag("reason", "unsupported encoding")
+	}
+
+	for i, f := range t.Params.Files {
+		switch {
+		case f.Name == "":
+			return errors.New("invalid share target").
+				WithTag("file-index", i).
+				WithTag("reason", "file param name is empty")
+
+		case len(f.Accept) == 0:
+			return errors.New("invalid share target").
+				WithTag("file-index", i).
+				WithTag("reason", "file param does not accept any file")
+		}
+	}
+
+	return nil
+}
+
+func validateFileHandler(fh FileHandler) error {
+	switch {
+	case !strings.HasPrefix(fh.Action, "/"):
+		return errors.New("action is not a route path").WithTag("action", fh.Action)
+
+	case len(fh.Accept) == 0:
+		return errors.New("accept is empty")
+
+	case fh.LaunchType != "" && fh.LaunchType != "single-client" && fh.LaunchType != "multiple-clients":
+		return errors.New("launch type is neither single-client or multiple-clients").
+			WithTag("launch-type", fh.LaunchType)
+	}
+
+	for mediaType, extensions := range fh.Accept {
+		if _, _, err := mime.ParseMediaType(mediaType); err != nil || !strings.Contains(mediaType, "/") {
+			return errors.New("accepted media type is invalid").WithTag("media-type", mediaType)
+		}
+
+		for _, ext := range extensions {
+			if !strings.HasPrefix(ext, ".") || len(ext) == 1 {
+				return errors.New("accepted file extension is invalid").
+					WithTag("media-type", mediaType).
+					WithTag("extension", ext)
+			}
+		}
+	}
+
+	return validateManifestIcons(fh.Icons)
+}
+
+func validateProtocolHandler(ph ProtocolHandler) error {
+	protocol := strings.ToLower(ph.Protocol)
+
+	switch {
+	case !containsString(safelistedSchemes, protocol) && !customSchemeRegexp.MatchString(protocol):
+		return errors.New("protocol is neither safelisted or prefixed by web+").
+			WithTag("protocol", ph.Protocol)
+
+	case !strings.HasPrefix(ph.URL, "/"):
+		return errors.New("url is not a route path").WithTag("url", ph.URL)
+
+	case !strings.Contains(ph.URL, "%s"):
+		return errors.New("url does not contain %s").WithTag("url", ph.URL)
+	}
+	return nil
+}
+
+// makeManifestJSON returns the web app manifest for the given language. The
+// fields of the localized manifest registered for the language override the
+// default ones.
+func (h *Handler) makeManifestJSON(lang string) []byte {
+	normalize := func(s string) string {
+		if !strings.HasPrefix(s, "/") {
+			s = "/" + s
+		}
+		if !strings.HasSuffix(s, "/") {
+			s += "/"
+		}
+		return s
+	}
+
+	m := manifest{
+		ID:          h.ManifestID,
+		Lang:        h.Lang,
+		ShortName:   h.ShortName,
+		Name:        h.Name,
+		Description: h.Description,
+		Icons: []ManifestIcon{
+			{
+				Src:   h.Icon.SVG,
+				Type:  "image/svg+xml",
+				Sizes: "any",
+			},
+			{
+				Src:   h.Icon.Large,
+				Type:  "image/png",
+				Sizes: "512x512",
+			},
+			{
+				Src:   h.Icon.Default,
+				Type:  "image/png",
+				Sizes: "192x192",
+			},
+		},
+		Scope:           normalize(h.rootPrefix()),
+		StartURL:        normalize(h.rootPrefix()),
+		BackgroundColor: h.BackgroundColor,
+		ThemeColor:      h.ThemeColor,
+		Display:         "standalone",
+		DisplayOverride: h.DisplayOverride,
+		Categories:      h.Categories,
+		Shortcuts:       h.Shortcuts,
+		Screenshots:     h.Screenshots,
+	}
+
+	if localized, ok := h.LocalizedManifests[lang]; ok {
+		m.Lang = lang
+		if localized.Name != "" {
+			m.Name = localized.Name
+		}
+		if localized.ShortName != "" {
+			m.ShortName = localized.ShortName
+		}
+		if localized.Description != "" {
+			m.Description = localized.Description
+		}
+		if localized.Shortcuts != nil {
+			m.Shortcuts = localized.Shortcuts
+		}
+		if localized.Screenshots != nil {
+			m.Screenshots = localized.Screenshots
+		}
+	}
+
+	m.Shortcuts = h.resolveManifestShortcuts(m.Shortcuts)
+	m.Screenshots = h.resolveManifestScreenshots(m.Screenshots)
+
+	if t := h.ShareTarget; t != nil {
+		shareTarget := *t
+		shareTarget.Action = h.resolveRoutePath(t.Action)
+		m.ShareTarget = &shareTarget
+	}
+
+	for _, fh := range h.FileHandlers {
+		fh.Action = h.resolveRoutePath(fh.Action)
+		fh.Icons = h.resolveManifestIcons(fh.Icons)
+		m.FileHandlers = append(m.FileHandlers, fh)
+	}
+
+	for _, ph := range h.ProtocolHandlers {
+		ph.Protocol = strings.ToLower(ph.Protocol)
+		ph.URL = h.resolveRoutePath(ph.URL)
+		m.ProtocolHandlers = append(m.ProtocolHandlers, ph)
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		panic(errors.New("initializing manifest.webmanifest failed").
+			WithTag("lang", lang).
+			Wrap(err))
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n"))
+}
+
+func (h *Handler) resolveManifestShortcuts(shortcuts []ManifestShortcut) []ManifestShortcut {
+	if len(shortcuts) == 0 {
+		return nil
+	}
+
+	resolved := make([]ManifestShortcut, len(shortcuts))
+	for i, s := range shortcuts {
+		s.URL = h.resolveRoutePath(s.URL)
+		s.Icons = h.resolveManifestIcons(s.Icons)
+		resolved[i] = s
+	}
+	return resolved
+}
+
+func (h *Handler) resolveManifestScreenshots(screenshots []ManifestScreenshot) []ManifestScreenshot {
+	if len(screenshots) == 0 {
+		return nil
+	}
+
+	resolved := make([]ManifestScreenshot, len(screenshots))
+	for i, s := range screenshots {
+		s.Src = h.resolveStaticPath(s.Src)
+		resolved[i] = s
+	}
+	return resolved
+}
+
+func (h *Handler) resolveManifestIcons(icons []ManifestIcon) []ManifestIcon {
+	if len(icons) == 0 {
+		return nil
+	}
+
+	resolved := make([]ManifestIcon, len(icons))
+	for i, icon := range icons {
+		icon.Src = h.resolveStaticPath(icon.Src)
+		resolved[i] = icon
+	}
+	return resolved
+}
+
+// resolveRoutePath returns the URL of the given route path, prefixed with the
+// root prefix.
+func (h *Handler) resolveRoutePath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return path
+	}
+	return h.rootPrefix() + path
+}
+
+// localizedManifestPath returns the path of the manifest of the given
+// language. Languages without localized manifest, either for the full
+// language tag or for its primary subtag, use the default manifest.
+func (h *Handler) localizedManifestPath(lang string) string {
+	if _, ok := h.LocalizedManifests[lang]; ok {
+		return "/manifest." + lang + ".webmanifest"
+	}
+
+	if primary, _, ok := strings.Cut(lang, "-"); ok {
+		if _, ok := h.LocalizedManifests[primary]; ok {
+			return "/manifest." + primary + ".webmanifest"
+		}
+	}
+
+	return "/manifest.webmanifest"
+}
+
+// readShareTargetData returns the data that is shared with the app when the
+// given request is sent to the share target action. It reports whether the
+// request is a share target request.
+func (h *Handler) readShareTargetData(w http.ResponseWriter, r *http.Request) (ShareTargetData, bool, error) {
+	t := h.ShareTarget
+	if t == nil || r.URL.Path != t.Action || r.Method != t.Method {
+		return ShareTargetData{}, false, nil
+	}
+
+	values := r.URL.Query()
+	if t.Method == http.MethodPost {
+		r.Body = http.MaxBytesReader(w, r.Body, shareTargetMaxBodySize)
+
+		var err error
+		if t.Enctype == "multipart/form-data" {
+			err = r.ParseMultipartForm(shareTargetMaxMemory)
+		} else {
+			err = r.ParseForm()
+		}
+		if err != nil {
+			return ShareTargetData{}, true, errors.New("parsing share target form failed").Wrap(err)
+		}
+		values = r.PostForm
+	}
+
+	data := ShareTargetData{
+		Title: shareTargetValue(values, t.Params.Title),
+		Text:  shareTargetValue(values, t.Params.Text),
+		URL:   shareTargetValue(values, t.Params.URL),
+	}
+
+	if r.MultipartForm == nil {
+		return data, true, nil
+	}
+
+	for _, param := range t.Params.Files {
+		for _, header := range r.MultipartForm.File[param.Name] {
+			file := SharedFile{
+				Name: header.Filename,
+				Type: header.Header.Get("Content-Type"),
+				Size: header.Size,
+			}
+
+			if header.Size <= shareTargetMaxFileSize {
+				b, err := readSharedFile(header)
+				if err != nil {
+					return data, true, errors.New("reading shared file failed").
+						WithTag("param", param.Name).
+						WithTag("filename", header.Filename).
+						Wrap(err)
+				}
+				file.Data = b
+			}
+
+			data.Files = append(data.Files, file)
+		}
+	}
+
+	return data, true, nil
+}
+
+func readSharedFile(header *multipart.FileHeader) ([]byte, error) {
+	f, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(io.LimitReader(f, shareTargetMaxFileSize))
+}
+
+// withoutFileData returns a copy of the data where the shared files do not
+// have their content. It is used to pass the data to the client without
+// embedding the files in the page.
+func (d *ShareTargetData) withoutFileData() *ShareTargetData {
+	if d == nil || len(d.Files) == 0 {
+		return d
+	}
+
+	data := *d
+	data.Files = make([]SharedFile, len(d.Files))
+	for i, f := range d.Files {
+		f.Data = nil
+		data.Files[i] = f
+	}
+	return &data
+}
+
+func shareTargetValue(values url.Values, param string) string {
+	if param == "" {
+		return ""
+	}
+	return values.Get(param)
+}
+
+// readPreRenderedShareTargetData returns the data shared with the app that is
+// written in the server-side pre-rendered page.
+func readPreRenderedShareTargetData() (ShareTargetData, bool) {
+	var data ShareTargetData
+	if !readJSONScript(shareTargetDataID, &data) {
+		return ShareTargetData{}, false
+	}
+	return data, true
+}
+
+func onFileLaunch(d ClientDispatcher) func(this Value, args []Value) any {
+	return func(this Value, args []Value) any {
+		if len(args) == 0 {
+			return nil
+		}
+
+		handles := args[0].Get("files")
+		count := handles.Length()
+		if count == 0 {
+			return nil
+		}
+
+		files := make([]SharedFile, count)
+		read := make([]bool, count)
+		remaining := count
+		done := func() {
+			if remaining--; remaining != 0 {
+				return
+			}
+
+			launched := make([]SharedFile, 0, count)
+			for i, f := range files {
+				if read[i] {
+					launched = append(launched, f)
+				}
+			}
+			if len(launched) != 0 {
+				d.launchFiles(launched)
+			}
+		}
+
+		for i := 0; i < count; i++ {
+			i := i
+			fail := func(reason Value) {
+				Log(errors.New("reading launched file failed").
+					WithTag("index", i).
+					WithTag("reason", jsErrorMessage(reason)))
+				done()
+			}
+
+			awaitPromise(handles.Index(i).Call("getFile"), func(file Value) {
+				awaitPromise(file.Call("arrayBuffer"), func(buffer Value) {
+					data := make([]byte, buffer.Get("byteLength").Int())
+					CopyBytesToGo(data, Window().Get("Uint8Array").New(buffer))
+
+					files[i] = SharedFile{
+						Name: file.Get("name").String(),
+						Type: file.Get("type").String(),
+						Size: int64(len(data)),
+						Data: data,
+					}
+					read[i] = true
+					done()
+				}, fail)
+			}, fail)
+		}
+		return nil
+	}
+}
+
+// awaitPromise calls resolve with the value of the given promise once it is
+// fulfilled, or reject with the reason it is rejected for.
+func awaitPromise(p Value, resolve, reject func(Value)) {
+	var onFulfilled, onRejected Func
+	settle := func(f func(Value), args []Value) {
+		onFulfilled.Release()
+		onRejected.Release()
+
+		var arg Value
+		if len(args) > 0 {
+			arg = args[0]
+		}
+		f(arg)
+	}
+
+	onFulfilled = FuncOf(func(this Value, args []Value) any {
+		settle(resolve, args)
+		return nil
+	})
+	onRejected = FuncOf(func(this Value, args []Value) any {
+		settle(reject, args)
+		return nil
+	})
+	p.Call("then", onFulfilled, onRejected)
+}
+
+func jsErrorMessage(v Value) string {
+	if v == nil || !v.Truthy() {
+		return ""
+	}
+	if msg := v.Get("message"); msg.Truthy() {
+		return msg.String()
+	}
+	return v.Call("toString").String()
+}
@@ -0,0 +1,597 @@
+//go:build !wasm
+// +build !wasm
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type shareTargetTestCompo struct {
+	Compo
+
+	data ShareTargetData
+}
+
+func (c *shareTargetTestCompo) OnShareTarget(ctx Context, data ShareTargetData) {
+	c.data = data
+}
+
+func (c *shareTargetTestCompo) Render() UI {
+	var files []string
+	for _, f := range c.data.Files {
+		files = append(files, fmt.Sprintf("%s:%s:%s", f.Name, f.Type, f.Data))
+	}
+
+	return P().Text(fmt.Sprintf("shared: %s|%s|%s|%s",
+		c.data.Title,
+		c.data.Text,
+		c.data.URL,
+		strings.Join(files, ","),
+	))
+}
+
+func TestHandlerServeManifestJSONExtendedFields(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/manifest.webmanifest", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{
+		Name:            "foobar",
+		BasePath:        "/admin",
+		ManifestID:      "/?app=foobar",
+		DisplayOverride: []string{"window-controls-overlay", "standalone"},
+		Categories:      []string{"productivity"},
+		Shortcuts: []ManifestShortcut{
+			{
+				Name: "Compose",
+				URL:  "/compose",
+				Icons: []ManifestIcon{
+					{Src: "/web/compose.png", Sizes: "96x96"},
+				},
+			},
+		},
+		Screenshots: []ManifestScreenshot{
+			{Src: "/web/wide.png", Sizes: "1280x720", FormFactor: "wide"},
+		},
+		ShareTarget: &ShareTarget{
+			Action: "/share",
+			Params: ShareTargetParams{
+				Files: []ShareTargetFiles{
+					{Name: "images", Accept: []string{"image/*"}},
+				},
+			},
+		},
+		FileHandlers: []FileHandler{
+			{
+				Action: "/open",
+				Accept: map[string][]string{"text/markdown": {".md"}},
+			},
+		},
+		ProtocolHandlers: []ProtocolHandler{
+			{Protocol: "web+Foo", URL: "/foo?url=%s"},
+		},
+	}
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var m manifest
+	err := json.Unmarshal(w.Body.Bytes(), &m)
+	require.NoError(t, err)
+
+	require.Equal(t, "/?app=foobar", m.ID)
+	require.Equal(t, "en", m.Lang)
+	require.Equal(t, "/admin/", m.Scope)
+	require.Equal(t, []string{"window-controls-overlay", "standalone"}, m.DisplayOverride)
+	require.Equal(t, []string{"productivity"}, m.Categories)
+
+	require.Len(t, m.Shortcuts, 1)
+	require.Equal(t, "/admin/compose", m.Shortcuts[0].URL)
+	require.Equal(t, "/admin/web/compose.png", m.Shortcuts[0].Icons[0].Src)
+
+	require.Len(t, m.Screenshots, 1)
+	require.Equal(t, "/admin/web/wide.png", m.Screenshots[0].Src)
+	require.Equal(t, "wide", m.Screenshots[0].FormFactor)
+
+	require.NotNil(t, m.ShareTarget)
+	require.Equal(t, "/admin/share", m.ShareTarget.Action)
+	require.Equal(t, http.MethodPost, m.ShareTarget.Method)
+	require.Equal(t, "multipart/form-data", m.ShareTarget.Enctype)
+	require.Equal(t, "images", m.ShareTarget.Params.Files[0].Name)
+
+	require.Len(t, m.FileHandlers, 1)
+	require.Equal(t, "/admin/open", m.FileHandlers[0].Action)
+	require.Equal(t, []string{".md"}, m.FileHandlers[0].Accept["text/markdown"])
+
+	require.Len(t, m.ProtocolHandlers, 1)
+	require.Equal(t, "web+foo", m.ProtocolHandlers[0].Protocol)
+	require.Equal(t, "/admin/foo?url=%s", m.ProtocolHandlers[0].URL)
+
+	require.Equal(t, "/web/compose.png", h.Shortcuts[0].Icons[0].Src)
+	require.Equal(t, "/compose", h.Shortcuts[0].URL)
+}
+
+func TestHandlerServeManifestJSONOmitsUnsetFields(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/manifest.webmanifest", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{Name: "foobar"}
+	h.ServeHTTP(w, r)
+
+	body := w.Body.String()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, body, `"display": "standalone"`)
+	require.NotContains(t, body, `"id"`)
+	require.NotContains(t, body, `"shortcuts"`)
+	require.NotContains(t, body, `"share_target"`)
+	require.NotContains(t, body, `"file_handlers"`)
+	require.NotContains(t, body, `"protocol_handlers"`)
+}
+
+func TestHandlerServeLocalizedManifests(t *testing.T) {
+	h := Handler{
+		Name:        "hello",
+		Description: "An app that says hello.",
+		Lang:        "fr-CA",
+		Shortcuts: []ManifestShortcut{
+			{Name: "Hello", URL: "/hello"},
+		},
+		LocalizedManifests: map[string]LocalizedManifest{
+			"fr": {
+				Name: "bonjour",
+				Shortcuts: []ManifestShortcut{
+					{Name: "Bonjour", URL: "/bonjour"},
+				},
+			},
+		},
+	}
+
+	t.Run("localized manifest is served", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/manifest.fr.webmanifest", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/manifest+json", w.Header().Get("Content-Type"))
+
+		var m manifest
+		err := json.Unmarshal(w.Body.Bytes(), &m)
+		require.NoError(t, err)
+		require.Equal(t, "fr", m.Lang)
+		require.Equal(t, "bonjour", m.Name)
+		require.Equal(t, "An app that says hello.", m.Description)
+		require.Equal(t, "/bonjour", m.Shortcuts[0].URL)
+	})
+
+	t.Run("default manifest is served", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/manifest.webmanifest", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var m manifest
+		err := json.Unmarshal(w.Body.Bytes(), &m)
+		require.NoError(t, err)
+		require.Equal(t, "fr-CA", m.Lang)
+		require.Equal(t, "hello", m.Name)
+		require.Equal(t, "/hello", m.Shortcuts[0].URL)
+	})
+
+	t.Run("page links the manifest of its language", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `href="/manifest.fr.webmanifest"`)
+	})
+
+	t.Run("localized manifest is cached by the service worker", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/app-worker.js", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"/manifest.fr.webmanifest"`)
+	})
+}
+
+func TestHandlerLocalizedManifestPath(t *testing.T) {
+	h := Handler{
+		LocalizedManifests: map[string]LocalizedManifest{
+			"fr":    {},
+			"pt-BR": {},
+		},
+	}
+
+	require.Equal(t, "/manifest.fr.webmanifest", h.localizedManifestPath("fr"))
+	require.Equal(t, "/manifest.fr.webmanifest", h.localizedManifestPath("fr-CA"))
+	require.Equal(t, "/manifest.pt-BR.webmanifest", h.localizedManifestPath("pt-BR"))
+	require.Equal(t, "/manifest.webmanifest", h.localizedManifestPath("pt"))
+	require.Equal(t, "/manifest.webmanifest", h.localizedManifestPath("en"))
+}
+
+func TestHandlerManifestValidation(t *testing.T) {
+	utests := []struct {
+		scenario string
+		handler  *Handler
+	}{
+		{
+			scenario: "absolute manifest id",
+			handler:  &Handler{ManifestID: "https://goapp.dev/"},
+		},
+		{
+			scenario: "unknown display override",
+			handler:  &Handler{DisplayOverride: []string{"floating"}},
+		},
+		{
+			scenario: "empty category",
+			handler:  &Handler{Categories: []string{" "}},
+		},
+		{
+			scenario: "shortcut without name",
+			handler: &Handler{
+				Shortcuts: []ManifestShortcut{{URL: "/compose"}},
+			},
+		},
+		{
+			scenario: "shortcut with remote url",
+			handler: &Handler{
+				Shortcuts: []ManifestShortcut{{Name: "Compose", URL: "https://goapp.dev/compose"}},
+			},
+		},
+		{
+			scenario: "shortcut icon without src",
+			handler: &Handler{
+				Shortcuts: []ManifestShortcut{{Name: "Compose", URL: "/compose", Icons: []ManifestIcon{{}}}},
+			},
+		},
+		{
+			scenario: "screenshot without src",
+			handler: &Handler{
+				Screenshots: []ManifestScreenshot{{FormFactor: "wide"}},
+			},
+		},
+		{
+			scenario: "screenshot with unknown form factor",
+			handler: &Handler{
+				Screenshots: []ManifestScreenshot{{Src: "/web/a.png", FormFactor: "square"}},
+			},
+		},
+		{
+			scenario: "share target without action",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{Params: ShareTargetParams{Text: "text"}},
+			},
+		},
+		{
+			scenario: "share target without params",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{Action: "/share"},
+			},
+		},
+		{
+			scenario: "share target with unsupported method",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{
+					Action: "/share",
+					Method: "PUT",
+					Params: ShareTargetParams{Text: "text"},
+				},
+			},
+		},
+		{
+			scenario: "share target with files and get method",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{
+					Action: "/share",
+					Method: "get",
+					Params: ShareTargetParams{
+						Files: []ShareTargetFiles{{Name: "f", Accept: []string{"image/*"}}},
+					},
+				},
+			},
+		},
+		{
+			scenario: "share target with files and url encoding",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{
+					Action:  "/share",
+					Enctype: "application/x-www-form-urlencoded",
+					Params: ShareTargetParams{
+						Files: []ShareTargetFiles{{Name: "f", Accept: []string{"image/*"}}},
+					},
+				},
+			},
+		},
+		{
+			scenario: "share target with files that are not accepted",
+			handler: &Handler{
+				ShareTarget: &ShareTarget{
+					Action: "/share",
+					Params: ShareTargetParams{
+						Files: []ShareTargetFiles{{Name: "f"}},
+					},
+				},
+			},
+		},
+		{
+			scenario: "file handler without accept",
+			handler: &Handler{
+				FileHandlers: []FileHandler{{Action: "/open"}},
+			},
+		},
+		{
+			scenario: "file handler with invalid extension",
+			handler: &Handler{
+				FileHandlers: []FileHandler{{
+					Action: "/open",
+					Accept: map[string][]string{"text/markdown": {"md"}},
+				}},
+			},
+		},
+		{
+			scenario: "file handler with invalid media type",
+			handler: &Handler{
+				FileHandlers: []FileHandler{{
+					Action: "/open",
+					Accept: map[string][]string{"markdown": {".md"}},
+				}},
+			},
+		},
+		{
+			scenario: "file handler with unknown launch type",
+			handler: &Handler{
+				FileHandlers: []FileHandler{{
+					Action:     "/open",
+					Accept:     map[string][]string{"text/markdown": {".md"}},
+					LaunchType: "new-window",
+				}},
+			},
+		},
+		{
+			scenario: "protocol handler with unprefixed scheme",
+			handler: &Handler{
+				ProtocolHandlers: []ProtocolHandler{{Protocol: "foo", URL: "/foo?url=%s"}},
+			},
+		},
+		{
+			scenario: "protocol handler without placeholder",
+			handler: &Handler{
+				ProtocolHandlers: []ProtocolHandler{{Protocol: "web+foo", URL: "/foo"}},
+			},
+		},
+		{
+			scenario: "localized manifest with invalid language tag",
+			handler: &Handler{
+				LocalizedManifests: map[string]LocalizedManifest{"fr/../x": {}},
+			},
+		},
+		{
+			scenario: "localized manifest with invalid shortcut",
+			handler: &Handler{
+				LocalizedManifests: map[string]LocalizedManifest{
+					"fr": {Shortcuts: []ManifestShortcut{{URL: "/compose"}}},
+				},
+			},
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			require.Panics(t, u.handler.initManifest)
+		})
+	}
+
+	t.Run("valid manifest", func(t *testing.T) {
+		h := Handler{
+			ManifestID:      "/",
+			DisplayOverride: []string{"tabbed"},
+			ShareTarget: &ShareTarget{
+				Action: "/share",
+				Method: "post",
+				Params: ShareTargetParams{Text: "text"},
+			},
+			ProtocolHandlers: []ProtocolHandler{{Protocol: "mailto", URL: "/compose?to=%s"}},
+		}
+		require.NotPanics(t, h.initManifest)
+		require.Equal(t, http.MethodPost, h.ShareTarget.Method)
+		require.Equal(t, "application/x-www-form-urlencoded", h.ShareTarget.Enctype)
+	})
+}
+
+func TestHandlerServeShareTarget(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		shareTarget := &ShareTarget{
+			Action: "/share",
+			Params: ShareTargetParams{
+				Title: "t",
+				Text:  "body",
+				URL:   "link",
+			},
+		}
+		h := Handler{ShareTarget: shareTarget}
+
+		r := httptest.NewRequest(http.MethodGet, "/share?t=hello&body=world&link="+url.QueryEscape("https://goapp.dev"), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, "shared: hello|world|https://goapp.dev|")
+		require.Contains(t, body, `<script id="goapp-share-target-data" type="application/json">{"title":"hello","text":"world","url":"https://goapp.dev"}</script>`)
+		require.Empty(t, shareTarget.Method)
+		require.Equal(t, http.MethodGet, h.ShareTarget.Method)
+	})
+
+	t.Run("multipart post", func(t *testing.T) {
+		h := Handler{
+			ShareTarget: &ShareTarget{
+				Action: "/share",
+				Params: ShareTargetParams{
+					Title: "title",
+					Files: []ShareTargetFiles{
+						{Name: "docs", Accept: []string{"text/plain"}},
+					},
+				},
+			},
+		}
+
+		var b bytes.Buffer
+		mw := multipart.NewWriter(&b)
+		err := mw.WriteField("title", "notes")
+		require.NoError(t, err)
+		fw, err := mw.CreateFormFile("docs", "notes.txt")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("hello"))
+		require.NoError(t, err)
+		err = mw.Close()
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/share", &b)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, "shared: notes|||notes.txt:application/octet-stream:hello")
+		require.Contains(t, body, `"files":[{"name":"notes.txt","type":"application/octet-stream","size":5}]`)
+		require.NotContains(t, body, "aGVsbG8=")
+	})
+
+	t.Run("multipart post with large file", func(t *testing.T) {
+		h := Handler{
+			BasePath: "/admin",
+			ShareTarget: &ShareTarget{
+				Action: "/share",
+				Params: ShareTargetParams{
+					Files: []ShareTargetFiles{
+						{Name: "docs", Accept: []string{"text/plain"}},
+					},
+				},
+			},
+		}
+
+		var b bytes.Buffer
+		mw := multipart.NewWriter(&b)
+		fw, err := mw.CreateFormFile("docs", "notes.txt")
+		require.NoError(t, err)
+		_, err = fw.Write(make([]byte, shareTargetMaxFileSize+1))
+		require.NoError(t, err)
+		err = mw.Close()
+		require.NoError(t, err)
+
+		tmpFiles := func() []string {
+			files, err := filepath.Glob(filepath.Join(os.TempDir(), "multipart-*"))
+			require.NoError(t, err)
+			return files
+		}
+		filesBefore := tmpFiles()
+
+		r := httptest.NewRequest(http.MethodPost, "/admin/share", &b)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, "shared: |||notes.txt:application/octet-stream:<")
+		require.Contains(t, body, `"files":[{"name":"notes.txt","type":"application/octet-stream","size":8388609}]`)
+		require.Equal(t, filesBefore, tmpFiles())
+	})
+
+	t.Run("multipart post too large", func(t *testing.T) {
+		h := Handler{
+			ShareTarget: &ShareTarget{
+				Action: "/share",
+				Params: ShareTargetParams{
+					Title: "title",
+					Files: []ShareTargetFiles{
+						{Name: "docs", Accept: []string{"text/plain"}},
+					},
+				},
+			},
+		}
+
+		var b bytes.Buffer
+		mw := multipart.NewWriter(&b)
+		err := mw.WriteField("title", "notes")
+		require.NoError(t, err)
+		fw, err := mw.CreateFormFile("docs", "notes.txt")
+		require.NoError(t, err)
+		_, err = fw.Write(make([]byte, shareTargetMaxBodySize))
+		require.NoError(t, err)
+		err = mw.Close()
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/share", &b)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, "shared: |||")
+		require.NotContains(t, body, "notes.txt")
+	})
+
+	t.Run("method mismatch", func(t *testing.T) {
+		h := Handler{
+			ShareTarget: &ShareTarget{
+				Action: "/share",
+				Method: http.MethodPost,
+				Params: ShareTargetParams{Text: "text"},
+			},
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/share?text=hello", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, body, "shared: |||")
+		require.NotContains(t, body, "goapp-share-target-data")
+	})
+}
+
+func TestShareTargetDataWithoutFileData(t *testing.T) {
+	var nilData *ShareTargetData
+	require.Nil(t, nilData.withoutFileData())
+
+	data := &ShareTargetData{
+		Title: "notes",
+		Files: []SharedFile{
+			{Name: "notes.txt", Type: "text/plain", Size: 5, Data: []byte("hello")},
+		},
+	}
+	require.Equal(t, &ShareTargetData{
+		Title: "notes",
+		Files: []SharedFile{
+			{Name: "notes.txt", Type: "text/plain", Size: 5},
+		},
+	}, data.withoutFileData())
+	require.Equal(t, []byte("hello"), data.Files[0].Data)
+}
+
+func TestComponentFileLaunch(t *testing.T) {
+	compo := &fileLaunchTestCompo{}
+	client := NewClientTester(compo)
+	defer client.Close()
+
+	files := []SharedFile{
+		{Name: "notes.md", Type: "text/markdown", Data: []byte("# Notes")},
+	}
+	client.launchFiles(files)
+	client.Consume()
+	require.Equal(t, files, compo.files)
+}
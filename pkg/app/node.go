@@ -5,6 +5,8 @@ import (
 	"io"
 	"reflect"
 	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 // UI is the interface that describes a user interface element such as
@@ -26,10 +28,12 @@ type UI interface {
 	getDispatcher() Dispatcher
 	getAttributes() attributes
 	getEventHandlers() eventHandlers
+	getKey() any
 	getParent() UI
 	setParent(UI)
 	getChildren() []UI
 	mount(Dispatcher) error
+	hydrate(Dispatcher, *hydrationCursor) error
 	dismount()
 	canUpdateWith(UI) bool
 	updateWith(UI) error
@@ -58,6 +62,15 @@ func (k Kind) String() string {
 	case RawHTML:
 		return "raw"
 
+	case Fragment:
+		return "fragment"
+
+	case PortalElem:
+		return "portal"
+
+	case OutletElem:
+		return "outlet"
+
 	default:
 		return "undefined"
 	}
@@ -82,6 +95,18 @@ const (
 
 	// RawHTML represents an HTML element obtained from a raw HTML code snippet.
 	RawHTML
+
+	// Fragment represents a group of sibling elements that are rendered
+	// without a wrapping HTML element.
+	Fragment
+
+	// PortalElem represents an element that displays its children within
+	// another DOM element than the one of its parent.
+	PortalElem
+
+	// OutletElem represents the element where a layout component displays
+	// the component routed for the current page.
+	OutletElem
 )
 
 // FilterUIElems returns a filtered version of the given UI elements where
@@ -117,7 +142,7 @@ func FilterUIElems(v ...UI) []UI {
 		}
 
 		switch e.Kind() {
-		case SimpleText, HTML, Component, RawHTML:
+		case SimpleText, HTML, Component, RawHTML, Fragment, PortalElem, OutletElem:
 
 		case Selector:
 			replaceAt(i, e.getChildren()...)
@@ -130,6 +155,340 @@ func FilterUIElems(v ...UI) []UI {
 	return v
 }
 
+// Keyed sets the key that identifies the given UI element among its siblings
+// and returns the element.
+//
+// When a parent element is updated, keyed children are matched by key rather
+// than by position. It allows lists to be reordered, or to have items inserted
+// and removed, without rewriting every following element or dismounting
+// components that keep a state.
+//
+// HTML elements can also be keyed with their Key method. Keyed is mostly meant
+// for components. It panics if the element is neither an HTML element nor a
+// component, or if the key is not comparable.
+func Keyed(key any, n UI) UI {
+	k, ok := n.(keyer)
+	if !ok {
+		panic(errors.New("ui element cannot be keyed").
+			WithTag("kind", n.Kind()).
+			WithTag("name", n.name()),
+		)
+	}
+
+	k.setKey(key)
+	return n
+}
+
+type keyer interface {
+	setKey(any)
+}
+
+func checkKey(v any) {
+	if v != nil && !reflect.TypeOf(v).Comparable() {
+		panic(errors.New("key is not comparable").
+			WithTag("key-type", reflect.TypeOf(v)),
+		)
+	}
+}
+
+// duplicateKey returns the first key that is shared by several of the given
+// children. It returns nil when all the keys are unique.
+func duplicateKey(children []UI) any {
+	keys := make(map[any]struct{}, len(children))
+	for _, c := range children {
+		k := c.getKey()
+		if k == nil {
+			continue
+		}
+		if _, ok := keys[k]; ok {
+			return k
+		}
+		keys[k] = struct{}{}
+	}
+	return nil
+}
+
+func hasKeyedChildren(children []UI) bool {
+	for _, c := range children {
+		if c.getKey() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// stableIndexes reports, for each element of the given old indexes, whether it
+// belongs to their longest increasing subsequence. Negative indexes are never
+// part of it.
+//
+// It is used to determine which reused children keep their relative position
+// in the DOM and do not need to be moved.
+func stableIndexes(oldIndexes []int) []bool {
+	stable := make([]bool, len(oldIndexes))
+	predecessors := make([]int, len(oldIndexes))
+	var tails []int
+
+	for i, idx := range oldIndexes {
+		if idx < 0 {
+			continue
+		}
+
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if oldIndexes[tails[mid]] < idx {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		predecessors[i] = -1
+		if lo > 0 {
+			predecessors[i] = tails[lo-1]
+		}
+
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	if len(tails) == 0 {
+		return stable
+	}
+
+	for i := tails[len(tails)-1]; i >= 0; i = predecessors[i] {
+		stable[i] = true
+	}
+	return stable
+}
+
+// updateChildren updates the given children of the parent element with the new
+// children and returns the resulting children. Children DOM nodes are attached
+// to jsParent, and new ones are inserted before end, or appended to jsParent
+// when end is nil.
+//
+// Keyed children are matched by key. When siblings share a key, an error is
+// logged and children are matched by position.
+func updateChildren(parent UI, jsParent Value, end Wrapper, children, newChildren []UI) ([]UI, error) {
+	if hasKeyedChildren(children) || hasKeyedChildren(newChildren) {
+		dup := duplicateKey(newChildren)
+		if dup == nil {
+			dup = duplicateKey(children)
+		}
+		if dup == nil {
+			return updateKeyedChildren(parent, jsParent, end, children, newChildren)
+		}
+
+		Log(errors.New("matching keyed children failed").
+			WithTag("parent", parent.name()).
+			WithTag("key", dup).
+			WithTag("reason", "several children share the same key, children are matched by position"))
+	}
+
+	i := 0
+	for ; i < len(children) && i < len(newChildren); i++ {
+		a := children[i]
+		b := newChildren[i]
+
+		if canUpdate(a, b) {
+			if err := update(a, b); err != nil {
+				return children, errors.New("updating child failed").
+					WithTag("child", reflect.TypeOf(a)).
+					WithTag("new-child", reflect.TypeOf(b)).
+					WithTag("index", i).
+					Wrap(err)
+			}
+			continue
+		}
+
+		if err := mount(parent.getDispatcher(), b); err != nil {
+			return children, errors.New("replacing child failed").
+				WithTag("child", reflect.TypeOf(a)).
+				WithTag("new-child", reflect.TypeOf(b)).
+				WithTag("index", i).
+				Wrap(err)
+		}
+
+		children[i] = b
+		b.setParent(parent.self())
+		replaceJSNodes(jsParent, b, a)
+		dismount(a)
+	}
+
+	for j := i; j < len(children); j++ {
+		removeJSNodes(jsParent, children[j])
+		dismount(children[j])
+		children[j] = nil
+	}
+	children = children[:i]
+
+	for ; i < len(newChildren); i++ {
+		b := newChildren[i]
+
+		if err := mount(parent.getDispatcher(), b); err != nil {
+			return children, errors.New("appending child failed").
+				WithTag("child", reflect.TypeOf(b)).
+				WithTag("index", i).
+				Wrap(err)
+		}
+
+		b.setParent(parent.self())
+		insertJSNodes(jsParent, b, end)
+		children = append(children, b)
+	}
+
+	return children, nil
+}
+
+func updateKeyedChildren(parent UI, jsParent Value, end Wrapper, children, newChildren []UI) ([]UI, error) {
+	keyedIndexes := make(map[any]int, len(children))
+	var unkeyedIndexes []int
+
+	for i, c := range children {
+		if k := c.getKey(); k != nil {
+			keyedIndexes[k] = i
+		} else {
+			unkeyedIndexes = append(unkeyedIndexes, i)
+		}
+	}
+
+	reused := make([]bool, len(children))
+	updatedChildren := make([]UI, 0, len(newChildren))
+	oldIndexes := make([]int, len(newChildren))
+
+	for i, b := range newChildren {
+		oldIndexes[i] = -1
+
+		j := -1
+		if k := b.getKey(); k != nil {
+			if idx, ok := keyedIndexes[k]; ok {
+				j = idx
+				delete(keyedIndexes, k)
+			}
+		} else if len(unkeyedIndexes) != 0 {
+			j = unkeyedIndexes[0]
+			unkeyedIndexes = unkeyedIndexes[1:]
+		}
+
+		if j >= 0 && canUpdate(children[j], b) {
+			a := children[j]
+			if err := update(a, b); err != nil {
+				return children, errors.New("updating keyed child failed").
+					WithTag("child", reflect.TypeOf(a)).
+					WithTag("new-child", reflect.TypeOf(b)).
+					WithTag("key", b.getKey()).
+					WithTag("index", i).
+					Wrap(err)
+			}
+
+			reused[j] = true
+			updatedChildren = append(updatedChildren, a)
+			oldIndexes[i] = j
+			continue
+		}
+
+		if err := mount(parent.getDispatcher(), b); err != nil {
+			return children, errors.New("mounting keyed child failed").
+				WithTag("child", reflect.TypeOf(b)).
+				WithTag("key", b.getKey()).
+				WithTag("index", i).
+				Wrap(err)
+		}
+		b.setParent(parent.self())
+		updatedChildren = append(updatedChildren, b)
+	}
+
+	for i, c := range children {
+		if !reused[i] {
+			removeJSNodes(jsParent, c)
+			dismount(c)
+		}
+	}
+
+	stable := stableIndexes(oldIndexes)
+	next := end
+	for i := len(updatedChildren) - 1; i >= 0; i-- {
+		c := updatedChildren[i]
+		if !stable[i] {
+			insertJSNodes(jsParent, c, next)
+		}
+		next = firstJSNode(c)
+	}
+
+	return updatedChildren, nil
+}
+
+// forEachJSNode calls the given function with each DOM node that represents the
+// given mounted element, in document order. A component is represented by the
+// nodes of its root and a fragment by the nodes of its children followed by
+// its anchor.
+func forEachJSNode(n UI, fn func(Value)) {
+	switch n.Kind() {
+	case Component:
+		forEachJSNode(n.getChildren()[0], fn)
+
+	case Fragment, OutletElem:
+		for _, c := range n.getChildren() {
+			forEachJSNode(c, fn)
+		}
+		fn(n.JSValue())
+
+	default:
+		fn(n.JSValue())
+	}
+}
+
+func firstJSNode(n UI) Value {
+	switch n.Kind() {
+	case Component:
+		return firstJSNode(n.getChildren()[0])
+
+	case Fragment, OutletElem:
+		if children := n.getChildren(); len(children) != 0 {
+			return firstJSNode(children[0])
+		}
+	}
+	return n.JSValue()
+}
+
+func insertJSNodes(jsParent Value, n UI, ref Wrapper) {
+	forEachJSNode(n, func(v Value) {
+		jsParent.insertBefore(v, ref)
+	})
+}
+
+func removeJSNodes(jsParent Value, n UI) {
+	forEachJSNode(n, func(v Value) {
+		jsParent.removeChild(v)
+	})
+}
+
+func replaceJSNodes(jsParent Value, new, old UI) {
+	insertJSNodes(jsParent, new, firstJSNode(old))
+	removeJSNodes(jsParent, old)
+}
+
+// jsParent returns the DOM node where the DOM nodes of the given element are
+// attached. It is the one of the nearest HTML element ancestor, or the target
+// of the nearest portal ancestor.
+func jsParent(n UI) (Value, bool) {
+	for p := n.getParent(); p != nil; p = p.getParent() {
+		switch p := p.(type) {
+		case *portal:
+			return p.jsTarget, true
+
+		default:
+			if p.Kind() == HTML {
+				return p.JSValue(), true
+			}
+		}
+	}
+	return nil, false
+}
+
 func mount(d Dispatcher, n UI) error {
 	n.setSelf(n)
 	return n.mount(d)
@@ -184,3 +543,14 @@ func PrintHTMLWithIndent(w io.Writer, ui UI) {
 	}
 	ui.htmlWithIndent(w, 0)
 }
+
+// forEachComponent calls the given function for the given element and its
+// descendants that are components.
+func forEachComponent(n UI, fn func(Composer)) {
+	if c, ok := n.(Composer); ok {
+		fn(c)
+	}
+	for _, c := range n.getChildren() {
+		forEachComponent(c, fn)
+	}
+}
@@ -32,6 +32,14 @@ func TestKindString(t *testing.T) {
 			kind:           Selector,
 			expectedString: "selector",
 		},
+		{
+			kind:           Fragment,
+			expectedString: "fragment",
+		},
+		{
+			kind:           PortalElem,
+			expectedString: "portal",
+		},
 	}
 
 	for _, u := range utests {
@@ -74,6 +82,75 @@ func BenchmarkFilterUIElems(b *testing.B) {
 	}
 }
 
+func TestKeyed(t *testing.T) {
+	t.Run("component", func(t *testing.T) {
+		c := &hello{}
+		require.Equal(t, c, Keyed(42, c))
+		require.Equal(t, 42, c.getKey())
+	})
+
+	t.Run("html element", func(t *testing.T) {
+		require.Equal(t, "foo", Keyed("foo", Div()).getKey())
+	})
+
+	t.Run("text panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			Keyed(42, Text("hello"))
+		})
+	})
+
+	t.Run("non comparable key panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			Div().Key([]int{42})
+		})
+	})
+}
+
+func TestDuplicateKey(t *testing.T) {
+	require.Nil(t, duplicateKey(nil))
+	require.Nil(t, duplicateKey([]UI{Div().Key(1), Div(), Div().Key(2), Div()}))
+	require.Equal(t, 2, duplicateKey([]UI{Div().Key(2), Div().Key(1), Div().Key(2)}))
+}
+
+func TestStableIndexes(t *testing.T) {
+	utests := []struct {
+		scenario   string
+		oldIndexes []int
+		expected   []bool
+	}{
+		{
+			scenario: "empty",
+			expected: []bool{},
+		},
+		{
+			scenario:   "same order",
+			oldIndexes: []int{0, 1, 2},
+			expected:   []bool{true, true, true},
+		},
+		{
+			scenario:   "inserted at top",
+			oldIndexes: []int{-1, 0, 1, 2},
+			expected:   []bool{false, true, true, true},
+		},
+		{
+			scenario:   "last moved to top",
+			oldIndexes: []int{2, 0, 1},
+			expected:   []bool{false, true, true},
+		},
+		{
+			scenario:   "reversed",
+			oldIndexes: []int{2, 1, 0},
+			expected:   []bool{false, false, true},
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			require.Equal(t, u.expected, stableIndexes(u.oldIndexes))
+		})
+	}
+}
+
 type mountTest struct {
 	scenario string
 	node     UI
@@ -2,7 +2,7 @@ package app
 
 var (
 	// NotFound is the ui element that is displayed when a request is not
-	// routed.
+	// routed. A component registered with RouteNotFound takes priority.
 	NotFound UI = &notFound{}
 )
 
@@ -0,0 +1,286 @@
+package app
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// Outlet returns the element where a layout component displays the component
+// routed for the current page. See Layout.
+//
+// It must be directly rendered by the layout component, not by one of its
+// nested components. Like fragments, a mounted outlet is linked to an empty
+// text node that marks its end in the DOM.
+func Outlet() UI {
+	return &outlet{}
+}
+
+type outlet struct {
+	layout     Composer
+	parentElem UI
+	child      UI
+
+	context       context.Context
+	contextCancel func()
+	dispatcher    Dispatcher
+	jsAnchor      Value
+}
+
+func (o *outlet) Kind() Kind {
+	return OutletElem
+}
+
+func (o *outlet) JSValue() Value {
+	return o.jsAnchor
+}
+
+func (o *outlet) Mounted() bool {
+	return o.context != nil && o.context.Err() == nil
+}
+
+func (o *outlet) name() string {
+	return "outlet"
+}
+
+func (o *outlet) self() UI {
+	return o
+}
+
+func (o *outlet) setSelf(UI) {
+}
+
+func (o *outlet) getContext() context.Context {
+	return o.context
+}
+
+func (o *outlet) getDispatcher() Dispatcher {
+	return o.dispatcher
+}
+
+func (o *outlet) getAttributes() attributes {
+	return nil
+}
+
+func (o *outlet) getEventHandlers() eventHandlers {
+	return nil
+}
+
+func (o *outlet) getKey() any {
+	return nil
+}
+
+func (o *outlet) getParent() UI {
+	return o.parentElem
+}
+
+func (o *outlet) setParent(p UI) {
+	o.parentElem = p
+}
+
+func (o *outlet) getChildren() []UI {
+	if o.child == nil {
+		return nil
+	}
+	return []UI{o.child}
+}
+
+func (o *outlet) mount(d Dispatcher) error {
+	return o.mountWith(d, func(child UI) error {
+		return mount(d, child)
+	})
+}
+
+func (o *outlet) hydrate(d Dispatcher, c *hydrationCursor) error {
+	return o.mountWith(d, func(child UI) error {
+		return hydrate(d, child, c)
+	})
+}
+
+func (o *outlet) mountWith(d Dispatcher, mountChild func(UI) error) error {
+	if o.Mounted() {
+		return errors.New("mounting outlet failed").
+			WithTag("reason", "already mounted").
+			WithTag("name", o.name()).
+			WithTag("kind", o.Kind())
+	}
+
+	o.context, o.contextCancel = context.WithCancel(d.getBaseContext())
+	o.dispatcher = d
+	o.jsAnchor = Window().createTextNode("")
+
+	if o.layout == nil {
+		return nil
+	}
+
+	child := o.layout.getRouted()
+	if child == nil {
+		return nil
+	}
+
+	// A mounted child is displayed by a previous outlet of the layout that is
+	// being replaced. It is moved rather than mounted again.
+	if child.Mounted() {
+		if previous, ok := child.getParent().(*outlet); ok {
+			previous.child = nil
+		}
+		child.setParent(o)
+		o.child = child
+		return nil
+	}
+
+	if err := mountChild(child); err != nil {
+		return errors.New("mounting outlet child failed").
+			WithTag("layout", o.layout.name()).
+			WithTag("child", child.name()).
+			Wrap(err)
+	}
+	child.setParent(o)
+	o.child = child
+	return nil
+}
+
+func (o *outlet) dismount() {
+	if o.contextCancel == nil {
+		return
+	}
+
+	if o.child != nil {
+		dismount(o.child)
+	}
+	o.contextCancel()
+}
+
+func (o *outlet) canUpdateWith(v UI) bool {
+	_, ok := v.(*outlet)
+	return ok && o.Mounted()
+}
+
+func (o *outlet) updateWith(v UI) error {
+	if !o.canUpdateWith(v) {
+		return errors.New("cannot update outlet with given element").
+			WithTag("current", reflect.TypeOf(o)).
+			WithTag("new", reflect.TypeOf(v))
+	}
+	return nil
+}
+
+// setChild displays the given component in the outlet. The current child is
+// updated when it can be, in which case it is the returned component.
+func (o *outlet) setChild(v Composer) (Composer, error) {
+	if o.child != nil && canUpdate(o.child, v) {
+		if err := update(o.child, v); err != nil {
+			return nil, errors.New("updating outlet child failed").Wrap(err)
+		}
+		return o.child.(Composer), nil
+	}
+
+	parent, ok := jsParent(o)
+	if !ok {
+		return nil, errors.New("setting outlet child failed").
+			WithTag("reason", "outlet does not have html element parents")
+	}
+
+	if err := mount(o.getDispatcher(), v); err != nil {
+		return nil, errors.New("mounting outlet child failed").
+			WithTag("child", v.name()).
+			Wrap(err)
+	}
+	v.setParent(o)
+
+	if o.child == nil {
+		insertJSNodes(parent, v, o.jsAnchor)
+	} else {
+		replaceJSNodes(parent, v, o.child)
+		dismount(o.child)
+	}
+	o.child = v
+	return v, nil
+}
+
+func (o *outlet) onComponentEvent(le any) {
+	if o.child != nil {
+		o.child.onComponentEvent(le)
+	}
+}
+
+func (o *outlet) html(w io.Writer) {
+	child := o.content()
+	if child == nil {
+		return
+	}
+
+	if child.self() == nil {
+		child.setSelf(child)
+	}
+	child.html(w)
+}
+
+func (o *outlet) htmlWithIndent(w io.Writer, indent int) {
+	child := o.content()
+	if child == nil {
+		return
+	}
+
+	if child.self() == nil {
+		child.setSelf(child)
+	}
+	child.htmlWithIndent(w, indent)
+}
+
+// content returns the element displayed by the outlet. Unmounted outlets
+// display the component routed by their layout.
+func (o *outlet) content() UI {
+	if o.child != nil {
+		return o.child
+	}
+	if o.layout != nil {
+		if c := o.layout.getRouted(); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// findOutlet returns the outlet rendered by the given layout component.
+func findOutlet(layout Composer) *outlet {
+	var find func(UI) *outlet
+	find = func(n UI) *outlet {
+		for _, c := range n.getChildren() {
+			switch c := c.(type) {
+			case *outlet:
+				if c.layout == layout {
+					return c
+				}
+
+			case Composer:
+
+			default:
+				if o := find(c); o != nil {
+					return o
+				}
+			}
+		}
+		return nil
+	}
+
+	return find(layout)
+}
+
+// bindOutlets links the outlets that are within the given element to the
+// given layout component. Outlets within nested components are ignored.
+func bindOutlets(layout Composer, n UI) {
+	switch n := n.(type) {
+	case *outlet:
+		n.layout = layout
+
+	case Composer:
+
+	default:
+		for _, c := range n.getChildren() {
+			bindOutlets(layout, c)
+		}
+	}
+}
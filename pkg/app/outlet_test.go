@@ -0,0 +1,172 @@
+package app
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type layoutTestCompo struct {
+	Compo
+
+	mounts   int
+	onNavURL string
+}
+
+func (l *layoutTestCompo) OnMount(Context) {
+	l.mounts++
+}
+
+func (l *layoutTestCompo) OnNav(ctx Context) {
+	l.onNavURL = ctx.Page().URL().String()
+}
+
+func (l *layoutTestCompo) Render() UI {
+	return Div().Body(
+		Nav().Text("menu"),
+		Main().Body(
+			Outlet(),
+		),
+	)
+}
+
+func TestOutletMountRoute(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	layout := &layoutTestCompo{}
+	page := &hello{}
+	e.mountRoute([]Composer{layout, page}, nil)
+	e.Consume()
+
+	require.True(t, layout.Mounted())
+	require.True(t, page.Mounted())
+	require.Equal(t, 1, layout.mounts)
+	require.Equal(t, Composer(page), layout.getRouted())
+
+	o := findOutlet(layout)
+	require.NotNil(t, o)
+	require.Equal(t, page, o.getChildren()[0])
+	require.Equal(t, o, page.getParent())
+
+	t.Run("layout persists across navigations", func(t *testing.T) {
+		newPage := &bar{}
+		e.mountRoute([]Composer{&layoutTestCompo{}, newPage}, nil)
+		e.Consume()
+
+		require.True(t, layout.Mounted())
+		require.Equal(t, 1, layout.mounts)
+		require.Equal(t, o, findOutlet(layout))
+		require.False(t, page.Mounted())
+		require.True(t, newPage.Mounted())
+		require.Equal(t, newPage, o.getChildren()[0])
+		require.Equal(t, layout, e.route[0])
+
+		u, _ := url.Parse("/bar")
+		e.Nav(u)
+		e.Consume()
+		require.Equal(t, "/bar", layout.onNavURL)
+		require.Equal(t, "/bar", newPage.onNavURL)
+	})
+
+	t.Run("layout is dismounted when not routed", func(t *testing.T) {
+		newPage := &hello{}
+		e.mountRoute([]Composer{newPage}, nil)
+		e.Consume()
+
+		require.False(t, layout.Mounted())
+		require.True(t, newPage.Mounted())
+		require.Equal(t, newPage, e.Body.getChildren()[0])
+	})
+}
+
+func TestOutletLayoutUpdate(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	layout := &layoutTestCompo{}
+	page := &hello{}
+	e.mountRoute([]Composer{layout, page}, nil)
+	e.Consume()
+
+	o := findOutlet(layout)
+	layout.Update()
+	e.Consume()
+
+	require.Equal(t, o, findOutlet(layout))
+	require.True(t, page.Mounted())
+	require.Equal(t, page, o.getChildren()[0])
+}
+
+func TestOutletWithoutLayout(t *testing.T) {
+	d := NewClientTester(Div().Body(Outlet()))
+	defer d.Close()
+
+	o := d.(*engine).Body.getChildren()[0].getChildren()[0]
+	require.True(t, o.Mounted())
+	require.Empty(t, o.getChildren())
+}
+
+func TestOutletMatch(t *testing.T) {
+	tree := Div().Body(Outlet())
+	d := NewClientTester(tree)
+	defer d.Close()
+
+	require.NoError(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Outlet(),
+	}))
+	require.Error(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: &outlet{child: Text("page")},
+	}))
+	require.Error(t, TestMatch(tree, TestUIDescriptor{
+		Path:     TestPath(0),
+		Expected: Frag(),
+	}))
+}
+
+func TestOutletHTML(t *testing.T) {
+	layout := &layoutTestCompo{}
+	layout.setRouted(&hello{Greeting: "world"})
+
+	html := HTMLString(layout)
+	require.Contains(t, html, "<nav>menu</nav>")
+	require.Contains(t, html, "<main><div><h1>")
+	require.Contains(t, html, "world")
+}
+
+type panicTestCompo struct {
+	Compo
+}
+
+func (c *panicTestCompo) Render() UI {
+	panic("render panic")
+}
+
+func TestEngineMountRouteWithErrorPage(t *testing.T) {
+	defer func(newErrorPage func(error) Composer) {
+		routes.newErrorPage = newErrorPage
+	}(routes.newErrorPage)
+
+	var pageErr error
+	routes.errorPage(func(err error) Composer {
+		pageErr = err
+		return &hello{}
+	})
+
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	e.mountRoute([]Composer{&layoutTestCompo{}, &panicTestCompo{}}, nil)
+	e.Consume()
+
+	require.Error(t, pageErr)
+	require.IsType(t, &hello{}, e.Body.getChildren()[0])
+	require.True(t, e.Body.getChildren()[0].Mounted())
+	require.Nil(t, e.route)
+}
@@ -1,8 +1,12 @@
 package app
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 // Page is the interface that describes a web page.
@@ -65,23 +69,67 @@ type Page interface {
 
 	// Set the Twitter card.
 	SetTwitterCard(v TwitterCard)
+
+	// Sets the status code of the HTTP response that contains the page.
+	//
+	// Only works when pre-rendering.
+	SetStatusCode(v int)
+
+	// Adds the given value to the header with the given name in the HTTP
+	// response that contains the page. The headers that are added replace the
+	// default ones with the same name.
+	//
+	// Only works when pre-rendering.
+	AddHeader(name, value string)
+
+	// Returns a copy of the HTTP request that the page is pre-rendered for. Its
+	// context carries the request-scoped values injected by the middlewares
+	// that wrap the handler.
+	//
+	// The copy is meant to be read: its body is always empty since the
+	// request body is owned by the handler, and changing it does not alter the
+	// request that is served.
+	//
+	// Returns nil in a web browser.
+	Request() *http.Request
+
+	// Returns the Content-Security-Policy nonce of the page. The script, style
+	// and link elements rendered by components are only allowed by the policy
+	// when they are given this nonce.
+	//
+	// Returns an empty string when the page has no Content-Security-Policy.
+	Nonce() string
+
+	// Redirects to the given URL. When pre-rendering, the HTTP response is a
+	// redirection with the given 3xx status code. In a web browser, the current
+	// page is replaced by the one at the given URL.
+	Redirect(url string, code int)
 }
 
 type requestPage struct {
 	url                   *url.URL
+	request               *http.Request
 	resolveStaticResource func(string) string
 
-	title          string
-	lang           string
-	description    string
-	author         string
-	keywords       string
-	preloads       []Preload
-	loadingLabel   string
-	image          string
-	width          int
-	height         int
-	twitterCardMap map[string]string
+	title           string
+	lang            string
+	description     string
+	author          string
+	keywords        string
+	preloads        []Preload
+	loadingLabel    string
+	image           string
+	width           int
+	height          int
+	twitterCardMap  map[string]string
+	statusCode      int
+	header          http.Header
+	redirectURL     string
+	redirectCode    int
+	loaderResult    *loaderResult
+	hydratedStates  map[string]json.RawMessage
+	shareTargetData *ShareTargetData
+	nonce           string
 }
 
 func (p *requestPage) Title() string {
@@ -164,9 +212,37 @@ func (p *requestPage) SetTwitterCard(v TwitterCard) {
 	p.twitterCardMap = v.toMap()
 }
 
+func (p *requestPage) SetStatusCode(v int) {
+	p.statusCode = v
+}
+
+func (p *requestPage) AddHeader(name, value string) {
+	if p.header == nil {
+		p.header = make(http.Header)
+	}
+	p.header.Add(name, value)
+}
+
+func (p *requestPage) Request() *http.Request {
+	return p.request
+}
+
+func (p *requestPage) Nonce() string {
+	return p.nonce
+}
+
+func (p *requestPage) Redirect(url string, code int) {
+	if code < 300 || code > 399 {
+		code = http.StatusFound
+	}
+	p.redirectURL = url
+	p.redirectCode = code
+}
+
 type browserPage struct {
 	url                   *url.URL
 	resolveStaticResource func(string) string
+	basePath              string
 }
 
 func (p browserPage) Title() string {
@@ -243,12 +319,12 @@ func (p browserPage) URL() *url.URL {
 	if p.url != nil {
 		return p.url
 	}
-	return Window().URL()
+	return toAppURL(Window().URL())
 }
 
 func (p browserPage) ReplaceURL(v *url.URL) {
 	Window().replaceHistory(v)
-	p.metaByProperty("og:url").setAttr("content", v.String())
+	p.metaByProperty("og:url").setAttr("content", toBrowserURL(v).String())
 }
 
 func (p browserPage) Size() (width int, height int) {
@@ -270,6 +346,39 @@ func (p browserPage) SetTwitterCard(v TwitterCard) {
 	}
 }
 
+func (p browserPage) SetStatusCode(v int) {
+}
+
+func (p browserPage) AddHeader(name, value string) {
+}
+
+func (p browserPage) Request() *http.Request {
+	return nil
+}
+
+func (p browserPage) Nonce() string {
+	// Browsers hide the nonce attribute value once the page is loaded while
+	// keeping it available from the nonce property.
+	script := Window().
+		Get("document").
+		Call("querySelector", "script[nonce]")
+	if !script.Truthy() {
+		return ""
+	}
+	return script.Get("nonce").String()
+}
+
+func (p browserPage) Redirect(rawURL string, code int) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		Log(errors.New("redirecting to URL failed").
+			WithTag("url", rawURL).
+			Wrap(err))
+		return
+	}
+	Window().Get("location").Call("replace", toBrowserURL(withBasePath(p.basePath, u)).String())
+}
+
 func (p browserPage) metaByName(v string) Value {
 	meta := Window().
 		Get("document").
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
@@ -16,6 +18,33 @@ func TestRequestPage(t *testing.T) {
 	})
 }
 
+func TestRequestPageResponse(t *testing.T) {
+	p := requestPage{}
+
+	p.SetStatusCode(http.StatusNotFound)
+	require.Equal(t, http.StatusNotFound, p.statusCode)
+
+	p.AddHeader("x-test", "foo")
+	p.AddHeader("X-Test", "bar")
+	require.Equal(t, []string{"foo", "bar"}, p.header.Values("X-Test"))
+
+	p.Redirect("/foo", http.StatusMovedPermanently)
+	require.Equal(t, "/foo", p.redirectURL)
+	require.Equal(t, http.StatusMovedPermanently, p.redirectCode)
+
+	require.Nil(t, p.Request())
+	p.request = httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NotNil(t, p.Request())
+
+	p.Redirect("/bar", http.StatusOK)
+	require.Equal(t, "/bar", p.redirectURL)
+	require.Equal(t, http.StatusFound, p.redirectCode)
+
+	require.Empty(t, p.Nonce())
+	p.nonce = "42"
+	require.Equal(t, "42", p.Nonce())
+}
+
 func TestBrowserPage(t *testing.T) {
 	testSkipNonWasm(t)
 
@@ -0,0 +1,237 @@
+package app
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// Portal returns an element that displays the given elements within the DOM
+// element with the given id, rather than within its parent HTML element.
+//
+// It is useful for modals, toasts or dropdowns that must not be clipped by
+// their ancestors. Portal elements keep their logical parent: they are
+// dismounted with it, and their events and updates propagate to its
+// components.
+//
+// The element with the given id must exist when the portal is mounted on the
+// client. When server-side pre-rendered, the portal content is written within
+// an inert template element.
+func Portal(targetID string, elems ...UI) UI {
+	return &portal{
+		targetID: targetID,
+		children: FilterUIElems(elems...),
+	}
+}
+
+type portal struct {
+	targetID   string
+	key        any
+	parentElem UI
+	children   []UI
+
+	context       context.Context
+	contextCancel func()
+	dispatcher    Dispatcher
+	jsAnchor      Value
+	jsTarget      Value
+	jsTargetEnd   Value
+}
+
+func (p *portal) Kind() Kind {
+	return PortalElem
+}
+
+func (p *portal) JSValue() Value {
+	return p.jsAnchor
+}
+
+func (p *portal) Mounted() bool {
+	return p.context != nil && p.context.Err() == nil
+}
+
+func (p *portal) name() string {
+	return "portal"
+}
+
+func (p *portal) self() UI {
+	return p
+}
+
+func (p *portal) setSelf(UI) {
+}
+
+func (p *portal) getContext() context.Context {
+	return p.context
+}
+
+func (p *portal) getDispatcher() Dispatcher {
+	return p.dispatcher
+}
+
+func (p *portal) getAttributes() attributes {
+	return nil
+}
+
+func (p *portal) getEventHandlers() eventHandlers {
+	return nil
+}
+
+func (p *portal) getKey() any {
+	return p.key
+}
+
+func (p *portal) setKey(v any) {
+	checkKey(v)
+	p.key = v
+}
+
+func (p *portal) getParent() UI {
+	return p.parentElem
+}
+
+func (p *portal) setParent(v UI) {
+	p.parentElem = v
+}
+
+func (p *portal) getChildren() []UI {
+	return p.children
+}
+
+func (p *portal) mount(d Dispatcher) error {
+	if p.Mounted() {
+		return errors.New("mounting portal failed").
+			WithTag("reason", "already mounted").
+			WithTag("name", p.name()).
+			WithTag("kind", p.Kind()).
+			WithTag("target-id", p.targetID)
+	}
+
+	target := Window().GetElementByID(p.targetID)
+	if IsClient && !target.Truthy() {
+		return errors.New("mounting portal failed").
+			WithTag("reason", "target not found").
+			WithTag("name", p.name()).
+			WithTag("kind", p.Kind()).
+			WithTag("target-id", p.targetID)
+	}
+
+	p.context, p.contextCancel = context.WithCancel(d.getBaseContext())
+	p.dispatcher = d
+	p.jsAnchor = Window().createTextNode("")
+	p.jsTarget = target
+
+	// The nodes of the children are kept before an empty text node that marks
+	// the end of the portal within the target, which preserves their position
+	// when they are updated while the target has other content.
+	p.jsTargetEnd = Window().createTextNode("")
+	p.jsTarget.appendChild(p.jsTargetEnd)
+
+	for i, c := range p.children {
+		if err := mount(d, c); err != nil {
+			return errors.New("mounting child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				WithTag("target-id", p.targetID).
+				Wrap(err)
+		}
+
+		c.setParent(p)
+		insertJSNodes(p.jsTarget, c, p.jsTargetEnd)
+	}
+
+	return nil
+}
+
+func (p *portal) hydrate(d Dispatcher, c *hydrationCursor) error {
+	if n, ok := c.peek(); ok &&
+		n.Get("tagName").String() == jsTemplateName &&
+		n.Call("getAttribute", "data-goapp-portal").String() == p.targetID {
+		c.next()
+	}
+	return p.mount(d)
+}
+
+func (p *portal) dismount() {
+	if p.contextCancel == nil {
+		return
+	}
+
+	for _, c := range p.children {
+		// Children whose mount has been interrupted by a panic do not have
+		// their nodes inserted in the target.
+		if c.getParent() == p {
+			removeJSNodes(p.jsTarget, c)
+		}
+		dismount(c)
+	}
+	p.jsTarget.removeChild(p.jsTargetEnd)
+	p.contextCancel()
+}
+
+func (p *portal) canUpdateWith(v UI) bool {
+	o, ok := v.(*portal)
+	return ok &&
+		p.Mounted() &&
+		p.targetID == o.targetID &&
+		p.getKey() == v.getKey()
+}
+
+func (p *portal) updateWith(v UI) error {
+	if !p.canUpdateWith(v) {
+		return errors.New("cannot update portal with given element").
+			WithTag("current", reflect.TypeOf(p)).
+			WithTag("new", reflect.TypeOf(v))
+	}
+
+	children, err := updateChildren(p, p.jsTarget, p.jsTargetEnd, p.children, v.getChildren())
+	p.children = children
+	return err
+}
+
+func (p *portal) onComponentEvent(le any) {
+	for _, c := range p.children {
+		c.onComponentEvent(le)
+	}
+}
+
+func (p *portal) html(w io.Writer) {
+	io.WriteString(w, `<template data-goapp-portal=`)
+	io.WriteString(w, strconv.Quote(p.targetID))
+	io.WriteString(w, ">")
+
+	for _, c := range p.children {
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.html(w)
+	}
+
+	io.WriteString(w, "</template>")
+}
+
+func (p *portal) htmlWithIndent(w io.Writer, indent int) {
+	writeIndent(w, indent)
+	io.WriteString(w, `<template data-goapp-portal=`)
+	io.WriteString(w, strconv.Quote(p.targetID))
+	io.WriteString(w, ">")
+
+	for _, c := range p.children {
+		io.WriteString(w, "\n")
+
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.htmlWithIndent(w, indent+1)
+	}
+
+	if len(p.children) != 0 {
+		io.WriteString(w, "\n")
+		writeIndent(w, indent)
+	}
+	io.WriteString(w, "</template>")
+}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortalMountDismount(t *testing.T) {
+	testMountDismount(t, []mountTest{
+		{
+			scenario: "portal",
+			node: Div().Body(
+				Portal("modal",
+					H1(),
+					&hello{},
+				),
+			),
+		},
+	})
+}
+
+func TestPortalUpdate(t *testing.T) {
+	testUpdate(t, []updateTest{
+		{
+			scenario: "portal children are updated",
+			a: Div().Body(
+				Portal("modal",
+					Text("hello"),
+				),
+			),
+			b: Div().Body(
+				Portal("modal",
+					Text("world"),
+					&bar{Value: "bar"},
+				),
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: Portal("modal"),
+				},
+				{
+					Path:     TestPath(0, 0),
+					Expected: Text("world"),
+				},
+				{
+					Path:     TestPath(0, 1),
+					Expected: &bar{Value: "bar"},
+				},
+			},
+		},
+		{
+			scenario: "portal with a different target is replaced",
+			a: Div().Body(
+				Portal("modal",
+					Text("hello"),
+				),
+			),
+			b: Div().Body(
+				Portal("toast",
+					Text("hello"),
+				),
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: Portal("toast"),
+				},
+			},
+		},
+	})
+}
+
+func TestPortalKeepsLogicalParent(t *testing.T) {
+	compo := &portalCompo{}
+	d := NewClientTester(compo)
+	defer d.Close()
+
+	child := compo.root.getChildren()[0].getChildren()[0]
+	require.Equal(t, compo, getComponent(child))
+
+	d.Emit(child, func() {
+		compo.Count++
+	})
+	d.Consume()
+	require.NoError(t, TestMatch(compo, TestUIDescriptor{
+		Path:     TestPath(0, 0, 0, 0),
+		Expected: Text(1),
+	}))
+}
+
+func TestPortalUpdateKeepsOrderInSharedTarget(t *testing.T) {
+	testSkipNonWasm(t)
+
+	target, err := Window().createElement("div", "")
+	require.NoError(t, err)
+	target.setAttr("id", "portal-shared-target")
+	target.appendChild(Window().createTextNode("x"))
+	body := Window().Get("document").Get("body")
+	body.appendChild(target)
+	defer body.removeChild(target)
+
+	compo := &sharedTargetPortalsCompo{First: []string{"a"}, Second: "b"}
+	d := NewClientTester(compo)
+	defer d.Close()
+	require.Equal(t, "xab", target.Get("textContent").String())
+
+	d.Emit(compo, func() {
+		compo.First = append(compo.First, "c")
+	})
+	d.Consume()
+	require.Equal(t, "xacb", target.Get("textContent").String())
+
+	d.Emit(compo, func() {
+		compo.First = nil
+	})
+	d.Consume()
+	require.Equal(t, "xb", target.Get("textContent").String())
+
+	d.Emit(compo, func() {
+		compo.First = []string{"d"}
+	})
+	d.Consume()
+	require.Equal(t, "xdb", target.Get("textContent").String())
+}
+
+func TestPortalHTML(t *testing.T) {
+	html := HTMLString(Div().Body(
+		Portal("modal",
+			P().Text("hello"),
+		),
+	))
+	require.Equal(t, `<div><template data-goapp-portal="modal"><p>hello</p></template></div>`, html)
+}
+
+type portalCompo struct {
+	Compo
+
+	Count int
+}
+
+func (c *portalCompo) Render() UI {
+	return Div().Body(
+		Portal("modal",
+			Span().Text(c.Count),
+		),
+	)
+}
+
+type sharedTargetPortalsCompo struct {
+	Compo
+
+	First  []string
+	Second string
+}
+
+func (c *sharedTargetPortalsCompo) Render() UI {
+	return Div().Body(
+		Portal("portal-shared-target",
+			Range(c.First).Slice(func(i int) UI {
+				return Text(c.First[i])
+			}),
+		),
+		Portal("portal-shared-target",
+			Text(c.Second),
+		),
+	)
+}
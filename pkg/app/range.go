@@ -17,6 +17,10 @@ type RangeLoop interface {
 	// Slice sets the loop content by repeating the given function for the
 	// number of elements in the source.
 	//
+	// Returned elements can be keyed with their Key method or with Keyed in
+	// order to be matched by key instead of position when the loop content
+	// changes.
+	//
 	// It panics if the range source is not a slice or an array.
 	Slice(f func(int) UI) RangeLoop
 
@@ -125,6 +129,10 @@ func (r rangeLoop) getEventHandlers() eventHandlers {
 	return nil
 }
 
+func (r rangeLoop) getKey() any {
+	return nil
+}
+
 func (r rangeLoop) getParent() UI {
 	return nil
 }
@@ -142,6 +150,10 @@ func (r rangeLoop) mount(Dispatcher) error {
 		WithTag("kind", r.Kind())
 }
 
+func (r rangeLoop) hydrate(d Dispatcher, c *hydrationCursor) error {
+	return r.mount(d)
+}
+
 func (r rangeLoop) dismount() {
 }
 
@@ -1,6 +1,10 @@
 package app
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TestRange(t *testing.T) {
 	testUpdate(t, []updateTest{
@@ -89,3 +93,15 @@ func TestRange(t *testing.T) {
 		},
 	})
 }
+
+func TestRangeMapDoesNotKeyElements(t *testing.T) {
+	loop := Range(map[string]int{"a": 1, "b": 2}).Map(func(k string) UI {
+		return Div().Text(k)
+	})
+
+	children := loop.(rangeLoop).body
+	require.Len(t, children, 2)
+	for _, c := range children {
+		require.Nil(t, c.getKey())
+	}
+}
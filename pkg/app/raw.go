@@ -74,6 +74,10 @@ func (r *raw) getEventHandlers() eventHandlers {
 	return nil
 }
 
+func (r *raw) getKey() any {
+	return nil
+}
+
 func (r *raw) getParent() UI {
 	return r.parentElem
 }
@@ -120,6 +124,26 @@ func (r *raw) mount(d Dispatcher) error {
 	return nil
 }
 
+func (r *raw) hydrate(d Dispatcher, c *hydrationCursor) error {
+	if r.Mounted() {
+		return r.mount(d)
+	}
+
+	jsElement, ok := c.next()
+	if err := r.mount(d); err != nil || !ok || !isMatchingJSElement(jsElement, r.tag) {
+		return err
+	}
+
+	// The pre-rendered node is kept only when it has the same content. It is
+	// otherwise left unadopted to be replaced by the freshly mounted one.
+	if jsElement.Get("outerHTML").String() != r.jsvalue.Get("outerHTML").String() {
+		return nil
+	}
+	c.adopt()
+	r.jsvalue = jsElement
+	return nil
+}
+
 func (r *raw) dismount() {
 	r.jsvalue = nil
 }
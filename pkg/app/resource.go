@@ -1,8 +1,13 @@
 package app
 
 import (
+	"io"
+	"io/fs"
 	"net/http"
+	"path"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ResourceProvider is the interface that describes a resource provider that
@@ -28,10 +33,16 @@ type ResourceProvider interface {
 
 // LocalDir returns a resource provider that serves static resources from a
 // local directory located at the given path.
+//
+// Files that have a precompressed sibling, such as web/app.wasm.br or
+// web/app.wasm.gz for web/app.wasm, are served compressed to the browsers that
+// accept Brotli or gzip encodings.
 func LocalDir(root string) ResourceProvider {
 	root = strings.Trim(root, "/")
 	return localDir{
 		Handler: http.FileServer(http.Dir(root)),
+		dir:     http.Dir(root),
+		etags:   newFileETags(),
 		root:    root,
 		appWASM: root + "/web/app.wasm",
 	}
@@ -39,10 +50,95 @@ func LocalDir(root string) ResourceProvider {
 
 type localDir struct {
 	http.Handler
+	dir     http.FileSystem
+	etags   *fileETags
 	root    string
 	appWASM string
 }
 
+// fileETags caches the entity tags of the files served from a local directory
+// by path and modification time.
+type fileETags struct {
+	mutex sync.Mutex
+	tags  map[string]fileETag
+}
+
+type fileETag struct {
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+func newFileETags() *fileETags {
+	return &fileETags{tags: make(map[string]fileETag)}
+}
+
+// get returns the entity tag of the given opened file. The file content is
+// only hashed when its modification time or size changed since the last call,
+// in which case the file is rewound once hashed.
+func (c *fileETags) get(name string, f http.File, stat fs.FileInfo) (string, error) {
+	c.mutex.Lock()
+	tag, ok := c.tags[name]
+	c.mutex.Unlock()
+	if ok && tag.modTime.Equal(stat.ModTime()) && tag.size == stat.Size() {
+		return tag.value, nil
+	}
+
+	hash, err := readerHash(f)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	tag = fileETag{
+		modTime: stat.ModTime(),
+		size:    stat.Size(),
+		value:   `"` + hash + `"`,
+	}
+	c.mutex.Lock()
+	c.tags[name] = tag
+	c.mutex.Unlock()
+	return tag.value, nil
+}
+
+// ServeHTTP serves the requested file. Its precompressed .br or .gz sibling is
+// served instead when it exists and when its encoding is accepted.
+//
+// Files are served with an entity tag built from their content, which lets
+// browsers revalidate them with If-None-Match. Entity tags are computed once
+// per file modification.
+func (d localDir) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Directories and redirected paths are left to the file server.
+	if strings.HasSuffix(r.URL.Path, "/") || strings.HasSuffix(r.URL.Path, "/index.html") {
+		d.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	name := path.Clean("/" + r.URL.Path)
+	f, err := d.dir.Open(name)
+	if err != nil {
+		d.Handler.ServeHTTP(w, r)
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil || stat.IsDir() {
+		d.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	if etag, err := d.etags.get(name, f, stat); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+
+	if !servePrecompressedFile(w, r, d.dir, name, stat.Size()) {
+		http.ServeContent(w, r, name, stat.ModTime(), f)
+	}
+}
+
 func (d localDir) Package() string {
 	return d.root
 }
@@ -100,6 +196,8 @@ func CustomProvider(path, prefix string) ResourceProvider {
 
 	return localDir{
 		Handler: http.FileServer(http.Dir(root)),
+		dir:     http.Dir(root),
+		etags:   newFileETags(),
 		root:    prefix,
 		appWASM: prefix + "/web/app.wasm",
 	}
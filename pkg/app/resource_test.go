@@ -7,8 +7,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -42,6 +44,55 @@ func TestLocalDir(t *testing.T) {
 	}
 }
 
+func TestLocalDirETag(t *testing.T) {
+	testSkipWasm(t)
+
+	close := testCreateDir(t, "test/web")
+	defer close()
+	testCreateFile(t, "test/web/hello.css", "p{}")
+
+	h := LocalDir("test")
+	serve := func(header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/web/hello.css", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res := httptest.NewRecorder()
+		h.(http.Handler).ServeHTTP(res, req)
+		return res
+	}
+
+	res := serve(nil)
+	etag := res.Header().Get("ETag")
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "p{}", res.Body.String())
+	require.Equal(t, `"`+contentHash([]byte("p{}"))+`"`, etag)
+
+	res = serve(http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, res.Code)
+
+	t.Run("cached by modification time", func(t *testing.T) {
+		stat, err := os.Stat("test/web/hello.css")
+		require.NoError(t, err)
+		testCreateFile(t, "test/web/hello.css", "a{}")
+		require.NoError(t, os.Chtimes("test/web/hello.css", stat.ModTime(), stat.ModTime()))
+
+		res := serve(nil)
+		require.Equal(t, etag, res.Header().Get("ETag"))
+		require.Equal(t, "a{}", res.Body.String())
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		modTime := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes("test/web/hello.css", modTime, modTime))
+
+		res := serve(http.Header{"If-None-Match": {etag}})
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, `"`+contentHash([]byte("a{}"))+`"`, res.Header().Get("ETag"))
+		require.Equal(t, "a{}", res.Body.String())
+	})
+}
+
 func TestRemoteBucket(t *testing.T) {
 	utests := []struct {
 		scenario string
@@ -1,9 +1,20 @@
 package app
 
 import (
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+
+	"github.com/google/uuid"
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	maxNavRedirects = 10
 )
 
 var (
@@ -12,10 +23,89 @@ var (
 
 // Route set the type of component to be mounted when a page is navigated to the
 // given path.
+//
+// The path can contain parameters that are captured from the navigated path
+// and retrieved with Context.Param:
+//   - {name} matches a single path segment.
+//   - {name...} matches the remaining path, slashes included. It must be at
+//     the end of the path.
+//   - {name:int} and {name:uuid} match a segment that can be parsed as an
+//     integer or as a UUID. Paths with a segment that cannot be parsed are not
+//     routed, which results in a 404 when pre-rendering.
+//
+// Eg:
+//
+//	app.Route("/users/{id:int}/posts/{slug...}", &post{})
+//
+// Paths without parameters take priority over the ones with parameters.
 func Route(path string, c Composer) {
 	RouteFunc(path, newZeroComponentFunc(c))
 }
 
+// NamedRoute is like Route, but also registers the path with the given name in
+// order to build the URLs that target it with URLFor.
+func NamedRoute(name, path string, c Composer) {
+	NamedRouteFunc(name, path, newZeroComponentFunc(c))
+}
+
+// NamedRouteFunc is like RouteFunc, but also registers the path with the given
+// name in order to build the URLs that target it with URLFor.
+func NamedRouteFunc(name, path string, newComponent func() Composer) {
+	routes.name(name, path)
+	RouteFunc(path, newComponent)
+}
+
+// URLFor returns the URL of the route registered with the given name, built
+// with the given parameters.
+//
+// The URL is relative to the app root. It is prefixed with the base path the
+// app is mounted under when it is used as an href attribute or navigated to
+// with Context.Navigate().
+//
+// It panics when there is no route with the given name, when a parameter of
+// the route path is missing or does not match its type, or when a path
+// parameter is not defined by the route path.
+//
+// Eg:
+//
+//	app.NamedRoute("post", "/users/{id:int}/posts/{slug...}", &post{})
+//
+//	app.URLFor("post",
+//		app.PathParam("id", 42),
+//		app.PathParam("slug", "go/app"),
+//		app.QueryParam("lang", "fr"),
+//	)
+//	// "/users/42/posts/go/app?lang=fr"
+func URLFor(name string, params ...URLParam) string {
+	return routes.urlFor(name, params...)
+}
+
+// URLParam represents a value used to build a URL with URLFor.
+type URLParam struct {
+	name  string
+	value any
+	query bool
+}
+
+// PathParam returns a parameter that sets the value of the path parameter with
+// the given name when building a URL with URLFor.
+func PathParam(name string, v any) URLParam {
+	return URLParam{
+		name:  name,
+		value: v,
+	}
+}
+
+// QueryParam returns a parameter that adds a value to the query parameter with
+// the given name when building a URL with URLFor.
+func QueryParam(name string, v any) URLParam {
+	return URLParam{
+		name:  name,
+		value: v,
+		query: true,
+	}
+}
+
 // RouteWithRegexp set the type of component to be mounted when a page is
 // navigated to a path that matches the given pattern.
 func RouteWithRegexp(pattern string, c Composer) {
@@ -26,6 +116,10 @@ func RouteWithRegexp(pattern string, c Composer) {
 // RouteFunc set a function that creates the component to be mounted when a page
 // is navigated to the given path.
 func RouteFunc(path string, newComponent func() Composer) {
+	if strings.Contains(path, "{") {
+		routes.routeWithParams(path, newComponent)
+		return
+	}
 	routes.route(path, newComponent)
 }
 
@@ -35,6 +129,110 @@ func RouteWithRegexpFunc(pattern string, newComponent func() Composer) {
 	routes.routeWithRegexp(pattern, newComponent)
 }
 
+// RouteNotFound set the type of component to be mounted when a page is
+// navigated to a path that is not routed. It replaces the NotFound element.
+//
+// The component is mounted within the layouts that match the path. On the
+// server, it is pre-rendered with a 404 status. GenerateStaticWebsite writes
+// it in a 404.html file.
+func RouteNotFound(c Composer) {
+	routes.notFound(newZeroComponentFunc(c))
+}
+
+// ErrorPage set a function that creates the component to be mounted when a page
+// fails to be displayed, with the error that made it fail. A page fails when
+// mounting or pre-rendering its components panics.
+//
+// On the server, the component is pre-rendered with a 500 status. Without
+// error page, the server responds with a plain text 500 Internal Server Error
+// and the client panics.
+func ErrorPage(newComponent func(err error) Composer) {
+	routes.errorPage(newComponent)
+}
+
+// Layout set the type of component that stays mounted while a page is
+// navigated to a path that starts with the given prefix. The component routed
+// for the path is displayed where the layout renders Outlet().
+//
+// Prefixes are matched by path segments: "/admin" matches "/admin" and
+// "/admin/users", but not "/administrator". Layouts can be nested, the ones
+// with the shortest prefix containing the others.
+//
+// Eg:
+//
+//	app.Layout("/", &shell{})
+//	app.Layout("/admin", &adminMenu{})
+//	app.Route("/admin/users", &users{})
+//	// "/admin/users" displays users within adminMenu, within shell.
+//
+// Navigating between paths that share a layout keeps the layout mounted and
+// only replaces its outlet content. Navigator.OnNav is called on both layouts
+// and routed components.
+func Layout(prefix string, c Composer) {
+	LayoutFunc(prefix, newZeroComponentFunc(c))
+}
+
+// LayoutFunc set a function that creates the layout component mounted while a
+// page is navigated to a path that starts with the given prefix. See Layout.
+func LayoutFunc(prefix string, newComponent func() Composer) {
+	routes.layout(prefix, newComponent)
+}
+
+// NavMiddleware is a function that decides whether a navigation from a URL to
+// another is performed. The from URL is nil when the page is loaded.
+type NavMiddleware func(ctx Context, from, to *url.URL) NavDecision
+
+// UseNavMiddleware adds the given middleware to the chain that is executed
+// before a page is navigated to:
+//   - On the client, when following a link, calling Context.Navigate or
+//     moving through the browser history with the back and forward buttons.
+//   - On the server, before the page is pre-rendered.
+//
+// Middlewares are executed in the order they are added, until one of them
+// cancels or redirects the navigation. Redirections are guarded by the chain
+// as well.
+//
+// A navigation canceled on the server responds with a 403 Forbidden status,
+// and a canceled page load on the client leaves the page empty. Redirecting
+// should be preferred when from is nil.
+//
+// Eg:
+//
+//	app.UseNavMiddleware(func(ctx app.Context, from, to *url.URL) app.NavDecision {
+//		if strings.HasPrefix(to.Path, "/admin") && !isSignedIn(ctx) {
+//			return app.RedirectNav("/login")
+//		}
+//		return app.AllowNav()
+//	})
+func UseNavMiddleware(m NavMiddleware) {
+	routes.useNavMiddleware(m)
+}
+
+// NavDecision represents the outcome of a navigation middleware. The zero
+// value allows the navigation.
+type NavDecision struct {
+	canceled    bool
+	redirectURL string
+}
+
+// AllowNav returns a decision that lets the navigation continue to the next
+// middleware.
+func AllowNav() NavDecision {
+	return NavDecision{}
+}
+
+// CancelNav returns a decision that stops the navigation. The current page
+// stays displayed.
+func CancelNav() NavDecision {
+	return NavDecision{canceled: true}
+}
+
+// RedirectNav returns a decision that navigates to the given URL instead. A
+// relative URL is resolved against the navigated one.
+func RedirectNav(rawURL string) NavDecision {
+	return NavDecision{redirectURL: rawURL}
+}
+
 func newZeroComponentFunc(c Composer) func() Composer {
 	componentType := reflect.TypeOf(c)
 
@@ -46,12 +244,21 @@ func newZeroComponentFunc(c Composer) func() Composer {
 type router struct {
 	mu               sync.RWMutex
 	routes           map[string]func() Composer
+	names            map[string]string
+	routesWithParams []paramRoute
 	routesWithRegexp []regexpRoute
+	layouts          []layoutRoute
+	navMiddlewares   []NavMiddleware
+	loaders          map[string]LoaderFunc
+	newNotFound      func() Composer
+	newErrorPage     func(error) Composer
 }
 
 func makeRouter() router {
 	return router{
-		routes: make(map[string]func() Composer),
+		routes:  make(map[string]func() Composer),
+		names:   make(map[string]string),
+		loaders: make(map[string]LoaderFunc),
 	}
 }
 
@@ -62,6 +269,31 @@ func (r *router) route(path string, newComponent func() Composer) {
 	r.routes[path] = newComponent
 }
 
+func (r *router) name(name, path string) {
+	parseRoutePath(path)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.names[name]; ok && p != path {
+		panic(errors.New("naming route failed").
+			WithTag("name", name).
+			WithTag("path", path).
+			WithTag("reason", "name is already used").
+			WithTag("named-path", p))
+	}
+	r.names[name] = path
+}
+
+func (r *router) routeWithParams(path string, newComponent func() Composer) {
+	route := newParamRoute(path, newComponent)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routesWithParams = append(r.routesWithParams, route)
+}
+
 func (r *router) routeWithRegexp(pattern string, newComponent func() Composer) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -72,28 +304,477 @@ func (r *router) routeWithRegexp(pattern string, newComponent func() Composer) {
 	})
 }
 
-func (r *router) createComponent(path string) (Composer, bool) {
+func (r *router) layout(prefix string, newComponent func() Composer) {
+	if prefix != "/" {
+		prefix = strings.TrimSuffix(prefix, "/")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, l := range r.layouts {
+		if l.prefix == prefix {
+			r.layouts[i].newComponent = newComponent
+			return
+		}
+	}
+
+	r.layouts = append(r.layouts, layoutRoute{
+		prefix:       prefix,
+		newComponent: newComponent,
+	})
+	sort.SliceStable(r.layouts, func(i, j int) bool {
+		return len(r.layouts[i].prefix) < len(r.layouts[j].prefix)
+	})
+}
+
+func (r *router) loader(path string, fn LoaderFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.loaders[path] = fn
+}
+
+// routeLoader returns the loader attached to the route that matches the given
+// path.
+func (r *router) routeLoader(path string) (LoaderFunc, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	newComponent, isRouted := r.routes[path]
-	if !isRouted {
-		for _, rwr := range r.routesWithRegexp {
-			if rwr.regexp.MatchString(path) {
-				newComponent = rwr.newComponent
-				isRouted = true
+	route, ok := r.match(path)
+	if !ok {
+		return nil, false
+	}
+
+	fn, ok := r.loaders[route.key]
+	return fn, ok
+}
+
+func (r *router) useNavMiddleware(m NavMiddleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.navMiddlewares = append(r.navMiddlewares, m)
+}
+
+// guardNav executes the navigation middlewares and returns the URL to navigate
+// to, which differs from the given one when redirected. Redirections to app
+// paths are prefixed with the given base path. It returns false when the
+// navigation is canceled.
+func (r *router) guardNav(ctx Context, basePath string, from, to *url.URL) (*url.URL, bool) {
+	r.mu.RLock()
+	middlewares := r.navMiddlewares
+	r.mu.RUnlock()
+
+	for redirects := 0; ; redirects++ {
+		decision := NavDecision{}
+		for _, m := range middlewares {
+			if decision = m(ctx, from, to); decision.canceled || decision.redirectURL != "" {
 				break
 			}
 		}
+
+		switch {
+		case decision.canceled:
+			return nil, false
+
+		case decision.redirectURL == "":
+			return to, true
+
+		case redirects >= maxNavRedirects:
+			Log(errors.New("navigation canceled").
+				WithTag("reason", "too many redirects").
+				WithTag("url", to.String()).
+				WithTag("redirects", redirects))
+			return nil, false
+		}
+
+		redirectURL, err := url.Parse(decision.redirectURL)
+		if err != nil {
+			Log(errors.New("navigation canceled").
+				WithTag("reason", "parsing redirect url failed").
+				WithTag("url", to.String()).
+				WithTag("redirect-url", decision.redirectURL).
+				Wrap(err))
+			return nil, false
+		}
+		to = to.ResolveReference(withBasePath(basePath, redirectURL))
+	}
+}
+
+func (r *router) notFound(newComponent func() Composer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.newNotFound = newComponent
+}
+
+func (r *router) errorPage(newComponent func(error) Composer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.newErrorPage = newComponent
+}
+
+// createNotFoundRoute returns the components to mount for the given path when
+// it is not routed: the layouts that match the path followed by the not found
+// component.
+func (r *router) createNotFoundRoute(path string) []Composer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	newNotFound := r.newNotFound
+	if newNotFound == nil {
+		newNotFound = newNotFoundElement
+	}
+
+	route := r.createLayouts(path)
+	return append(route, newNotFound())
+}
+
+// newNotFoundElement returns a new component of the type of the NotFound
+// element. The built-in not found component is returned when NotFound is not
+// a component.
+func newNotFoundElement() Composer {
+	if c, ok := NotFound.(Composer); ok && c != nil {
+		return newZeroComponentFunc(c)()
+	}
+	return &notFound{}
+}
+
+// createErrorRoute returns the components to mount to display the given error.
+// Layouts are not mounted since they can be the cause of the error. It
+// returns false when there is no error page.
+func (r *router) createErrorRoute(err error) ([]Composer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.newErrorPage == nil {
+		return nil, false
 	}
+	return []Composer{r.newErrorPage(err)}, true
+}
+
+// createRoute returns the components to mount for the given path: the layouts
+// that match the path, from the outermost to the innermost, followed by the
+// routed component.
+func (r *router) createRoute(path string) ([]Composer, bool) {
+	compo, isRouted := r.createComponent(path)
 	if !isRouted {
 		return nil, false
 	}
 
-	return newComponent(), true
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	route := r.createLayouts(path)
+	return append(route, compo), true
+}
+
+func (r *router) createLayouts(path string) []Composer {
+	layouts := make([]Composer, 0, len(r.layouts)+1)
+	for _, l := range r.layouts {
+		if l.match(path) {
+			layouts = append(layouts, l.newComponent())
+		}
+	}
+	return layouts
+}
+
+func (r *router) createComponent(path string) (Composer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	route, ok := r.match(path)
+	if !ok {
+		return nil, false
+	}
+	return route.newComponent(), true
+}
+
+// matchedRoute represents the route that matches a path.
+type matchedRoute struct {
+	// The path or the pattern the route is registered with.
+	key string
+
+	newComponent func() Composer
+
+	// The parameters captured from the path by a route with parameters.
+	params map[string]string
+}
+
+// match returns the route that matches the given path. Paths without
+// parameters take priority over the ones with parameters, which take priority
+// over regular expressions. The router must be locked by the caller.
+func (r *router) match(path string) (matchedRoute, bool) {
+	if newComponent, ok := r.routes[path]; ok {
+		return matchedRoute{
+			key:          path,
+			newComponent: newComponent,
+		}, true
+	}
+
+	for _, rwp := range r.routesWithParams {
+		if params, ok := rwp.match(path); ok {
+			return matchedRoute{
+				key:          rwp.path,
+				newComponent: rwp.newComponent,
+				params:       params,
+			}, true
+		}
+	}
+
+	for _, rwr := range r.routesWithRegexp {
+		if rwr.regexp.MatchString(path) {
+			return matchedRoute{
+				key:          rwr.regexp.String(),
+				newComponent: rwr.newComponent,
+			}, true
+		}
+	}
+
+	return matchedRoute{}, false
 }
 
 type regexpRoute struct {
 	regexp       *regexp.Regexp
 	newComponent func() Composer
 }
+
+type layoutRoute struct {
+	prefix       string
+	newComponent func() Composer
+}
+
+func (l layoutRoute) match(path string) bool {
+	return l.prefix == "/" ||
+		path == l.prefix ||
+		strings.HasPrefix(path, l.prefix+"/")
+}
+
+func (r *router) urlFor(name string, params ...URLParam) string {
+	r.mu.RLock()
+	path, ok := r.names[name]
+	r.mu.RUnlock()
+	if !ok {
+		panic(errors.New("building url failed").
+			WithTag("name", name).
+			WithTag("reason", "route not found"))
+	}
+
+	values := make(map[string]string, len(params))
+	query := make(url.Values)
+	for _, p := range params {
+		if p.query {
+			query.Add(p.name, toString(p.value))
+			continue
+		}
+		values[p.name] = toString(p.value)
+	}
+
+	var b strings.Builder
+	for _, part := range parseRoutePath(path) {
+		if part.name == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+
+		v, ok := values[part.name]
+		if !ok || (v == "" && !part.remaining) {
+			panic(errors.New("building url failed").
+				WithTag("name", name).
+				WithTag("path", path).
+				WithTag("parameter", part.name).
+				WithTag("reason", "missing parameter"))
+		}
+		if !isParamOfType(v, part.typ) {
+			panic(errors.New("building url failed").
+				WithTag("name", name).
+				WithTag("path", path).
+				WithTag("parameter", part.name).
+				WithTag("value", v).
+				WithTag("reason", "value is not a "+part.typ))
+		}
+		delete(values, part.name)
+
+		if !part.remaining {
+			b.WriteString(url.PathEscape(v))
+			continue
+		}
+		for i, segment := range strings.Split(v, "/") {
+			if i > 0 {
+				b.WriteByte('/')
+			}
+			b.WriteString(url.PathEscape(segment))
+		}
+	}
+
+	if len(values) != 0 {
+		unknown := make([]string, 0, len(values))
+		for name := range values {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+
+		panic(errors.New("building url failed").
+			WithTag("name", name).
+			WithTag("path", path).
+			WithTag("parameters", unknown).
+			WithTag("reason", "parameters are not defined by the route path"))
+	}
+
+	if len(query) != 0 {
+		b.WriteByte('?')
+		b.WriteString(query.Encode())
+	}
+	return b.String()
+}
+
+// params returns the parameters captured from the given path by the route
+// with parameters that matches it.
+func (r *router) params(path string) map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	route, _ := r.match(path)
+	return route.params
+}
+
+type paramRoute struct {
+	path         string
+	regexp       *regexp.Regexp
+	names        []string
+	types        []string
+	newComponent func() Composer
+}
+
+func newParamRoute(path string, newComponent func() Composer) paramRoute {
+	route := paramRoute{
+		path:         path,
+		newComponent: newComponent,
+	}
+
+	var pattern strings.Builder
+	pattern.WriteByte('^')
+
+	for _, part := range parseRoutePath(path) {
+		switch {
+		case part.name == "":
+			pattern.WriteString(regexp.QuoteMeta(part.literal))
+
+		case part.remaining:
+			pattern.WriteString("(.*)")
+
+		default:
+			pattern.WriteString("([^/]+)")
+		}
+
+		if part.name != "" {
+			route.names = append(route.names, part.name)
+			route.types = append(route.types, part.typ)
+		}
+	}
+
+	pattern.WriteByte('$')
+	route.regexp = regexp.MustCompile(pattern.String())
+	return route
+}
+
+func (r paramRoute) match(path string) (map[string]string, bool) {
+	values := r.regexp.FindStringSubmatch(path)
+	if values == nil {
+		return nil, false
+	}
+
+	params := make(map[string]string, len(r.names))
+	for i, name := range r.names {
+		v := values[i+1]
+		if !isParamOfType(v, r.types[i]) {
+			return nil, false
+		}
+		params[name] = v
+	}
+	return params, true
+}
+
+func isParamOfType(v, typ string) bool {
+	switch typ {
+	case "int":
+		_, err := strconv.Atoi(v)
+		return err == nil
+
+	case "uuid":
+		_, err := uuid.Parse(v)
+		return err == nil
+
+	default:
+		return true
+	}
+}
+
+// routePathPart represents either a literal or a parameter of a route path.
+type routePathPart struct {
+	literal   string
+	name      string
+	typ       string
+	remaining bool
+}
+
+func parseRoutePath(path string) []routePathPart {
+	var parts []routePathPart
+
+	for remaining := path; remaining != ""; {
+		start := strings.IndexByte(remaining, '{')
+		if start < 0 {
+			parts = append(parts, routePathPart{literal: remaining})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, routePathPart{literal: remaining[:start]})
+		}
+		remaining = remaining[start:]
+
+		end := strings.IndexByte(remaining, '}')
+		if end < 0 {
+			panic(errors.New("parsing route path failed").
+				WithTag("path", path).
+				WithTag("reason", "unclosed parameter"))
+		}
+		param := remaining[1:end]
+		remaining = remaining[end+1:]
+
+		name, typ, _ := strings.Cut(param, ":")
+		part := routePathPart{
+			name: strings.TrimSuffix(name, "..."),
+			typ:  typ,
+		}
+
+		if part.remaining = strings.HasSuffix(name, "..."); part.remaining && remaining != "" {
+			panic(errors.New("parsing route path failed").
+				WithTag("path", path).
+				WithTag("parameter", param).
+				WithTag("reason", "remaining path parameter is not at the end"))
+		}
+
+		switch typ {
+		case "", "int", "uuid":
+
+		default:
+			panic(errors.New("parsing route path failed").
+				WithTag("path", path).
+				WithTag("parameter", param).
+				WithTag("reason", "unsupported parameter type"))
+		}
+
+		if part.name == "" {
+			panic(errors.New("parsing route path failed").
+				WithTag("path", path).
+				WithTag("parameter", param).
+				WithTag("reason", "parameter does not have a name"))
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts
+}
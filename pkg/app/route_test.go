@@ -1,6 +1,8 @@
 package app
 
 import (
+	"errors"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -15,6 +17,10 @@ type routeWithRegexpCompo struct {
 	Compo
 }
 
+type routeWithParamsCompo struct {
+	Compo
+}
+
 func TestRoutes(t *testing.T) {
 	utests := []struct {
 		scenario     string
@@ -107,6 +113,80 @@ func TestRoutes(t *testing.T) {
 			},
 			notFound: true,
 		},
+		{
+			scenario: "path with parameters is routed",
+			path:     "/users/42/posts/foo/bar",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id}/posts/{slug...}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			expected: &routeWithParamsCompo{},
+		},
+		{
+			scenario: "path take priority over path with parameters",
+			path:     "/users/me",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id}", newZeroComponentFunc(&routeWithParamsCompo{}))
+				r.route("/users/me", newZeroComponentFunc(&routeCompo{}))
+			},
+			expected: &routeCompo{},
+		},
+		{
+			scenario: "path with parameters take priority over pattern",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.routeWithRegexp("^/users/.*$", newZeroComponentFunc(&routeWithRegexpCompo{}))
+				r.routeWithParams("/users/{id}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			expected: &routeWithParamsCompo{},
+		},
+		{
+			scenario: "path with a missing segment parameter is not routed",
+			path:     "/users//posts",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id}/posts", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with a segment parameter containing a slash is not routed",
+			path:     "/users/4/2",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with an int parameter is routed",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id:int}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			expected: &routeWithParamsCompo{},
+		},
+		{
+			scenario: "path with an invalid int parameter is not routed",
+			path:     "/users/abc",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id:int}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with an uuid parameter is routed",
+			path:     "/users/0b6f7a0e-4d5f-4b45-9b0a-0d0bd3b0b3c1",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id:uuid}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			expected: &routeWithParamsCompo{},
+		},
+		{
+			scenario: "path with an invalid uuid parameter is not routed",
+			path:     "/users/42",
+			createRoutes: func(r *router) {
+				r.routeWithParams("/users/{id:uuid}", newZeroComponentFunc(&routeWithParamsCompo{}))
+			},
+			notFound: true,
+		},
 	}
 
 	for _, u := range utests {
@@ -128,3 +208,315 @@ func TestRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestRouterParams(t *testing.T) {
+	r := makeRouter()
+	r.route("/users/me", newZeroComponentFunc(&routeCompo{}))
+	r.routeWithParams("/users/{id:int}/posts/{slug...}", newZeroComponentFunc(&routeWithParamsCompo{}))
+	r.routeWithParams("/users/{id}", newZeroComponentFunc(&routeWithParamsCompo{}))
+
+	require.Equal(t, map[string]string{
+		"id":   "42",
+		"slug": "foo/bar",
+	}, r.params("/users/42/posts/foo/bar"))
+
+	require.Equal(t, map[string]string{
+		"id":   "42",
+		"slug": "",
+	}, r.params("/users/42/posts/"))
+
+	require.Equal(t, map[string]string{"id": "maxence"}, r.params("/users/maxence"))
+	require.Nil(t, r.params("/users/me"))
+	require.Nil(t, r.params("/hello"))
+}
+
+func TestRouterMatch(t *testing.T) {
+	r := makeRouter()
+	r.route("/users/me", newZeroComponentFunc(&routeCompo{}))
+	r.routeWithParams("/users/{id}", newZeroComponentFunc(&routeWithParamsCompo{}))
+	r.routeWithRegexp("^/users/.*$", newZeroComponentFunc(&routeCompo{}))
+
+	route, ok := r.match("/users/me")
+	require.True(t, ok)
+	require.Equal(t, "/users/me", route.key)
+	require.Nil(t, route.params)
+
+	route, ok = r.match("/users/42")
+	require.True(t, ok)
+	require.Equal(t, "/users/{id}", route.key)
+	require.Equal(t, map[string]string{"id": "42"}, route.params)
+
+	route, ok = r.match("/users/42/posts")
+	require.True(t, ok)
+	require.Equal(t, "^/users/.*$", route.key)
+	require.Nil(t, route.params)
+
+	_, ok = r.match("/hello")
+	require.False(t, ok)
+}
+
+func TestNewParamRoute(t *testing.T) {
+	utests := []struct {
+		scenario string
+		path     string
+	}{
+		{
+			scenario: "unclosed parameter",
+			path:     "/users/{id",
+		},
+		{
+			scenario: "remaining path parameter is not at the end",
+			path:     "/users/{path...}/posts",
+		},
+		{
+			scenario: "unsupported parameter type",
+			path:     "/users/{id:float}",
+		},
+		{
+			scenario: "parameter without name",
+			path:     "/users/{}",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			require.Panics(t, func() {
+				newParamRoute(u.path, nil)
+			})
+		})
+	}
+}
+
+func TestRouterURLFor(t *testing.T) {
+	r := makeRouter()
+	r.name("home", "/")
+	r.name("user", "/users/{id:int}")
+	r.name("post", "/users/{id:int}/posts/{slug...}")
+	r.name("uuid", "/items/{id:uuid}")
+
+	t.Run("path without parameters", func(t *testing.T) {
+		require.Equal(t, "/", r.urlFor("home"))
+	})
+
+	t.Run("path with parameters", func(t *testing.T) {
+		require.Equal(t, "/users/42", r.urlFor("user", PathParam("id", 42)))
+	})
+
+	t.Run("path with remaining path parameter", func(t *testing.T) {
+		require.Equal(t, "/users/42/posts/go/hello%20world", r.urlFor("post",
+			PathParam("id", 42),
+			PathParam("slug", "go/hello world"),
+		))
+	})
+
+	t.Run("path with query", func(t *testing.T) {
+		require.Equal(t, "/users/42?lang=fr&tag=a&tag=b", r.urlFor("user",
+			PathParam("id", 42),
+			QueryParam("tag", "a"),
+			QueryParam("lang", "fr"),
+			QueryParam("tag", "b"),
+		))
+	})
+
+	t.Run("unknown name panics", func(t *testing.T) {
+		require.Panics(t, func() { r.urlFor("unknown") })
+	})
+
+	t.Run("missing parameter panics", func(t *testing.T) {
+		require.Panics(t, func() { r.urlFor("user") })
+		require.Panics(t, func() { r.urlFor("user", PathParam("id", "")) })
+	})
+
+	t.Run("invalid parameter panics", func(t *testing.T) {
+		require.Panics(t, func() { r.urlFor("user", PathParam("id", "abc")) })
+		require.Panics(t, func() { r.urlFor("uuid", PathParam("id", 42)) })
+	})
+
+	t.Run("undefined parameter panics", func(t *testing.T) {
+		require.Panics(t, func() { r.urlFor("user", PathParam("id", 42), PathParam("foo", "bar")) })
+	})
+
+	t.Run("name already used panics", func(t *testing.T) {
+		require.NotPanics(t, func() { r.name("user", "/users/{id:int}") })
+		require.Panics(t, func() { r.name("user", "/people/{id:int}") })
+	})
+}
+
+func TestNamedRoute(t *testing.T) {
+	NamedRoute("test-named-route", "/test-named-route/{id:int}", &routeCompo{})
+
+	u := URLFor("test-named-route", PathParam("id", 21))
+	require.Equal(t, "/test-named-route/21", u)
+
+	compo, ok := routes.createComponent(u)
+	require.True(t, ok)
+	require.IsType(t, &routeCompo{}, compo)
+
+	t.Run("with base path", func(t *testing.T) {
+		href := hrefWithBasePath("/admin", u)
+		require.Equal(t, "/admin/test-named-route/21", href)
+		require.Equal(t, map[string]string{"id": "21"}, routes.params(routePath("/admin", href)))
+	})
+}
+
+func TestRouterCreateRoute(t *testing.T) {
+	r := makeRouter()
+	r.route("/", newZeroComponentFunc(&routeCompo{}))
+	r.route("/admin", newZeroComponentFunc(&routeCompo{}))
+	r.route("/admin/users", newZeroComponentFunc(&routeCompo{}))
+	r.route("/administrator", newZeroComponentFunc(&routeCompo{}))
+	r.layout("/admin/", newZeroComponentFunc(&routeWithParamsCompo{}))
+	r.layout("/", newZeroComponentFunc(&routeWithRegexpCompo{}))
+
+	types := func(path string) []reflect.Type {
+		route, ok := r.createRoute(path)
+		require.True(t, ok)
+
+		types := make([]reflect.Type, 0, len(route))
+		for _, c := range route {
+			types = append(types, reflect.TypeOf(c))
+		}
+		return types
+	}
+
+	root := reflect.TypeOf(&routeWithRegexpCompo{})
+	admin := reflect.TypeOf(&routeWithParamsCompo{})
+	page := reflect.TypeOf(&routeCompo{})
+
+	require.Equal(t, []reflect.Type{root, page}, types("/"))
+	require.Equal(t, []reflect.Type{root, admin, page}, types("/admin"))
+	require.Equal(t, []reflect.Type{root, admin, page}, types("/admin/users"))
+	require.Equal(t, []reflect.Type{root, page}, types("/administrator"))
+
+	_, ok := r.createRoute("/unknown")
+	require.False(t, ok)
+}
+
+func TestRouterGuardNav(t *testing.T) {
+	r := makeRouter()
+	r.useNavMiddleware(func(ctx Context, from, to *url.URL) NavDecision {
+		switch to.Path {
+		case "/admin", "/shop/admin":
+			return RedirectNav("/login?next=admin")
+
+		case "/unsaved":
+			return CancelNav()
+
+		case "/loop":
+			return RedirectNav("/loop")
+
+		case "/invalid":
+			return RedirectNav(":invalid")
+
+		default:
+			return AllowNav()
+		}
+	})
+
+	var called []string
+	r.useNavMiddleware(func(ctx Context, from, to *url.URL) NavDecision {
+		called = append(called, to.Path)
+		return AllowNav()
+	})
+
+	guardWithBasePath := func(basePath, rawURL string) (string, bool) {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+
+		to, ok := r.guardNav(nil, basePath, nil, u)
+		if !ok {
+			return "", false
+		}
+		return to.String(), true
+	}
+
+	guard := func(rawURL string) (string, bool) {
+		return guardWithBasePath("", rawURL)
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		called = nil
+		u, ok := guard("https://murlok.io/hello")
+		require.True(t, ok)
+		require.Equal(t, "https://murlok.io/hello", u)
+		require.Equal(t, []string{"/hello"}, called)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		called = nil
+		u, ok := guard("https://murlok.io/admin")
+		require.True(t, ok)
+		require.Equal(t, "https://murlok.io/login?next=admin", u)
+		require.Equal(t, []string{"/login"}, called)
+	})
+
+	t.Run("redirect with base path", func(t *testing.T) {
+		called = nil
+		u, ok := guardWithBasePath("/shop", "https://murlok.io/shop/admin")
+		require.True(t, ok)
+		require.Equal(t, "https://murlok.io/shop/login?next=admin", u)
+		require.Equal(t, []string{"/shop/login"}, called)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		called = nil
+		_, ok := guard("/unsaved")
+		require.False(t, ok)
+		require.Empty(t, called)
+	})
+
+	t.Run("too many redirects cancels", func(t *testing.T) {
+		_, ok := guard("/loop")
+		require.False(t, ok)
+	})
+
+	t.Run("invalid redirect cancels", func(t *testing.T) {
+		_, ok := guard("/invalid")
+		require.False(t, ok)
+	})
+}
+
+func TestRouterCreateNotFoundRoute(t *testing.T) {
+	r := makeRouter()
+	r.layout("/admin", newZeroComponentFunc(&routeWithParamsCompo{}))
+
+	route := r.createNotFoundRoute("/hello")
+	require.Len(t, route, 1)
+	require.IsType(t, &notFound{}, route[0])
+
+	defaultNotFound := NotFound
+	defer func() {
+		NotFound = defaultNotFound
+	}()
+	NotFound = &hello{}
+	route = r.createNotFoundRoute("/hello")
+	require.Len(t, route, 1)
+	require.IsType(t, &hello{}, route[0])
+	require.NotSame(t, NotFound, route[0])
+
+	r.notFound(newZeroComponentFunc(&routeCompo{}))
+	route = r.createNotFoundRoute("/admin/hello")
+	require.Len(t, route, 2)
+	require.IsType(t, &routeWithParamsCompo{}, route[0])
+	require.IsType(t, &routeCompo{}, route[1])
+}
+
+func TestRouterCreateErrorRoute(t *testing.T) {
+	r := makeRouter()
+	r.layout("/", newZeroComponentFunc(&routeWithParamsCompo{}))
+
+	_, ok := r.createErrorRoute(errors.New("test"))
+	require.False(t, ok)
+
+	var pageErr error
+	r.errorPage(func(err error) Composer {
+		pageErr = err
+		return &routeCompo{}
+	})
+
+	route, ok := r.createErrorRoute(errors.New("test"))
+	require.True(t, ok)
+	require.Len(t, route, 1)
+	require.IsType(t, &routeCompo{}, route[0])
+	require.EqualError(t, pageErr, "test")
+}
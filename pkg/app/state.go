@@ -10,6 +10,10 @@ import (
 	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
+const (
+	hydratedStatesID = "goapp-states"
+)
+
 // Observer is an observer that observes changes for a given state.
 type Observer interface {
 	// Defines a condition that reports whether the observer keeps observing the
@@ -46,8 +50,13 @@ type State struct {
 	// Reports whether a state is broadcasted to other browser tabs and windows.
 	IsBroadcasted bool
 
-	value     any
-	observers map[*observer]struct{}
+	// Reports whether a state set during server-side pre-rendering is
+	// transferred to the client.
+	IsHydrated bool
+
+	value         any
+	hydratedValue json.RawMessage
+	observers     map[*observer]struct{}
 }
 
 func (s *State) isExpired(now time.Time) bool {
@@ -97,6 +106,17 @@ func Broadcast(s *State) {
 	s.IsBroadcasted = true
 }
 
+// Hydrate is a state option that transfers a state set during server-side
+// pre-rendering to the client. The state value is written as JSON in the
+// pre-rendered page and is available from the client store before the first
+// component is mounted.
+//
+// On the client, the transferred value is retrieved by decoding its JSON
+// representation into the receiver.
+func Hydrate(s *State) {
+	s.IsHydrated = true
+}
+
 type observer struct {
 	element    UI
 	subscribe  func(*observer)
@@ -171,6 +191,7 @@ func (s *store) Set(key string, v any, opts ...StateOption) {
 
 	state := s.states[key]
 	state.value = v
+	state.hydratedValue = nil
 	for _, o := range opts {
 		o(&state)
 	}
@@ -246,9 +267,14 @@ func (s *store) Get(key string, recv any) {
 		s.states[key] = state
 	}
 
-	if state.value != nil {
+	switch {
+	case state.value != nil:
 		err = storeValue(recv, state.value)
-	} else {
+
+	case state.hydratedValue != nil:
+		err = json.Unmarshal(state.hydratedValue, recv)
+
+	default:
 		err = s.getPersistent(key, recv)
 	}
 	if err != nil {
@@ -309,10 +335,54 @@ func (s *store) subscribe(key string, o *observer) error {
 	}
 	s.states[key] = state
 
-	if state.value != nil {
+	switch {
+	case state.value != nil:
 		return storeValue(o.receiver, state.value)
+
+	case state.hydratedValue != nil:
+		return json.Unmarshal(state.hydratedValue, o.receiver)
+
+	default:
+		return s.getPersistent(key, o.receiver)
+	}
+}
+
+// hydratedStates returns the JSON representation of the states set with the
+// Hydrate option.
+func (s *store) hydratedStates() map[string]json.RawMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	states := make(map[string]json.RawMessage)
+	for key, state := range s.states {
+		if !state.IsHydrated || state.value == nil || state.isExpired(time.Now()) {
+			continue
+		}
+
+		b, err := json.Marshal(state.value)
+		if err != nil {
+			Log(errors.New("encoding hydrated state failed").
+				WithTag("state", key).
+				Wrap(err))
+			continue
+		}
+		states[key] = b
+	}
+	return states
+}
+
+// seed sets the values of the states transferred from the server-side
+// pre-rendered page.
+func (s *store) seed(states map[string]json.RawMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, v := range states {
+		state := s.states[key]
+		state.IsHydrated = true
+		state.hydratedValue = v
+		s.states[key] = state
 	}
-	return s.getPersistent(key, o.receiver)
 }
 
 func (s *store) removeUnusedObservers() {
@@ -398,6 +468,7 @@ func (s *store) expireExpriredPersistentValues() {
 func (s *store) expire(key string, state State) State {
 	s.disp.getLocalStorage().Del(key)
 	state.value = nil
+	state.hydratedValue = nil
 	return state
 }
 
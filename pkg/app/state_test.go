@@ -103,6 +103,56 @@ func TestStore(t *testing.T) {
 	require.Empty(t, s.states)
 }
 
+func TestStoreHydrate(t *testing.T) {
+	server := NewServerTester(Div())
+	defer server.Close()
+
+	serverStore := newStore(server)
+	defer serverStore.Close()
+
+	type user struct {
+		Name string
+	}
+
+	serverStore.Set("/test/hydrate/user", user{Name: "Maxence"}, Hydrate)
+	serverStore.Set("/test/hydrate/int", 42, Hydrate)
+	serverStore.Set("/test/hydrate/ignored", 21)
+	serverStore.Set("/test/hydrate/expired", 84, Hydrate, ExpiresIn(-time.Second))
+
+	states := serverStore.hydratedStates()
+	require.Len(t, states, 2)
+	require.JSONEq(t, `{"Name":"Maxence"}`, string(states["/test/hydrate/user"]))
+	require.JSONEq(t, `42`, string(states["/test/hydrate/int"]))
+
+	client := NewClientTester(Div())
+	defer client.Close()
+
+	clientStore := newStore(client)
+	defer clientStore.Close()
+	clientStore.seed(states)
+
+	var u user
+	clientStore.Get("/test/hydrate/user", &u)
+	require.Equal(t, "Maxence", u.Name)
+
+	elem := &hello{}
+	client.Mount(elem)
+	client.Consume()
+
+	var i int
+	clientStore.Observe("/test/hydrate/int", elem).Value(&i)
+	require.Equal(t, 42, i)
+
+	clientStore.Set("/test/hydrate/int", 21)
+	clientStore.Get("/test/hydrate/int", &i)
+	require.Equal(t, 21, i)
+
+	clientStore.Set("/test/hydrate/int", nil)
+	i = 0
+	clientStore.Get("/test/hydrate/int", &i)
+	require.Zero(t, i)
+}
+
 func TestStorePersist(t *testing.T) {
 	d := NewClientTester(Div())
 	defer d.Close()
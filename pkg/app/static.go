@@ -27,6 +27,7 @@ func GenerateStaticWebsite(dir string, h *Handler, pages ...string) error {
 
 	resources := map[string]struct{}{
 		"/":                     {},
+		"/404.html":             {},
 		"/wasm_exec.js":         {},
 		"/app.js":               {},
 		"/app-worker.js":        {},
@@ -49,6 +50,16 @@ func GenerateStaticWebsite(dir string, h *Handler, pages ...string) error {
 		resources[p] = struct{}{}
 	}
 
+	// Fingerprinted resources are generated along with their original
+	// version. Localized manifests are generated along with the default one.
+	h.once.Do(h.init)
+	for path := range h.fingerprintedPaths {
+		resources[path] = struct{}{}
+	}
+	for lang := range h.LocalizedManifests {
+		resources[h.localizedManifestPath(lang)] = struct{}{}
+	}
+
 	server := httptest.NewServer(h)
 	defer server.Close()
 
@@ -123,6 +134,7 @@ func createStaticPage(path string) ([]byte, error) {
 			WithTag("path", path).
 			Wrap(err)
 	}
+	req.Header.Set("Accept", "text/html")
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
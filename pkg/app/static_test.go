@@ -33,6 +33,7 @@ func TestGenerateStaticWebsite(t *testing.T) {
 		filepath.Join(dir),
 		filepath.Join(dir, "web"),
 		filepath.Join(dir, "index.html"),
+		filepath.Join(dir, "404.html"),
 		filepath.Join(dir, "wasm_exec.js"),
 		filepath.Join(dir, "app.js"),
 		filepath.Join(dir, "app-worker.js"),
@@ -49,4 +50,56 @@ func TestGenerateStaticWebsite(t *testing.T) {
 			require.NoError(t, err)
 		})
 	}
+
+	notFound, err := os.ReadFile(filepath.Join(dir, "404.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(notFound), "goapp-notfound-title")
+}
+
+func TestGenerateStaticWebsiteWithFingerprintedResources(t *testing.T) {
+	testSkipWasm(t)
+
+	dir := "static-fingerprint-test"
+	defer os.RemoveAll(dir)
+
+	h := &Handler{
+		Resources:            GitHubPages("go-app"),
+		FingerprintResources: true,
+	}
+	err := GenerateStaticWebsite(dir, h)
+	require.NoError(t, err)
+
+	appJS, err := os.ReadFile(filepath.Join(dir, "app.js"))
+	require.NoError(t, err)
+
+	fingerprintedAppJS, err := os.ReadFile(filepath.Join(dir, fingerprintPath("/app.js", appJS)))
+	require.NoError(t, err)
+	require.Equal(t, appJS, fingerprintedAppJS)
+
+	_, err = os.Stat(filepath.Join(dir, fingerprintPath("/app.css", []byte(appCSS))))
+	require.NoError(t, err)
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(index), `src="/go-app`+fingerprintPath("/app.js", appJS)+`"`)
+}
+
+func TestGenerateStaticWebsiteWithSubresourceIntegrity(t *testing.T) {
+	testSkipWasm(t)
+
+	dir := "static-integrity-test"
+	defer os.RemoveAll(dir)
+
+	err := GenerateStaticWebsite(dir, &Handler{
+		Resources:            GitHubPages("go-app"),
+		SubresourceIntegrity: true,
+	})
+	require.NoError(t, err)
+
+	appJS, err := os.ReadFile(filepath.Join(dir, "app.js"))
+	require.NoError(t, err)
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(index), `integrity="`+integrityOf(appJS)+`"`)
 }
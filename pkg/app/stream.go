@@ -0,0 +1,425 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	streamMarker = "goapp-stream:"
+
+	streamScript = `function goappResolveStream(id) {
+  const script = document.currentScript;
+  const chunk = script.previousElementSibling;
+  const start = "goapp-stream:" + id;
+  const end = "/" + start;
+
+  const walker = document.createTreeWalker(document.body, NodeFilter.SHOW_COMMENT);
+  while (walker.nextNode()) {
+    const begin = walker.currentNode;
+    if (begin.data !== start) {
+      continue;
+    }
+
+    let next = begin.nextSibling;
+    while (next && !(next.nodeType === Node.COMMENT_NODE && next.data === end)) {
+      next.remove();
+      next = begin.nextSibling;
+    }
+    begin.after(chunk.content);
+    break;
+  }
+
+  chunk.remove();
+  script.remove();
+}`
+)
+
+// asyncTracker tracks the asynchronous operations that are launched while a
+// page is pre-rendered. When created to report completions, the element that
+// launched an operation is sent on the done channel once it finished.
+type asyncTracker struct {
+	mutex    sync.Mutex
+	pending  map[UI]int
+	late     map[UI]bool
+	count    int
+	running  int
+	idleChan chan struct{}
+	done     chan UI
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+func newAsyncTracker(reportDone bool) *asyncTracker {
+	t := &asyncTracker{
+		pending:  make(map[UI]int),
+		late:     make(map[UI]bool),
+		idleChan: make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	close(t.idleChan)
+	if reportDone {
+		t.done = make(chan UI, dispatcherSize)
+	}
+	return t
+}
+
+func (t *asyncTracker) start(src UI) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if src != nil {
+		t.pending[src]++
+	}
+	t.count++
+	t.run(1)
+}
+
+// finish reports that an operation launched from the given element is done.
+// Operations that finish after being canceled are remembered as late.
+//
+// When completions are reported, the operation keeps being counted until its
+// completion is received with receive or drain. This guarantees that a
+// tracker with counted operations always ends up having a completion to
+// receive. Completions are no longer reported once the tracker is stopped.
+func (t *asyncTracker) finish(src UI, canceled bool) {
+	t.mutex.Lock()
+	if src != nil {
+		if t.pending[src]--; t.pending[src] <= 0 {
+			delete(t.pending, src)
+		}
+		if canceled {
+			t.late[src] = true
+		}
+	}
+	if t.done == nil {
+		t.count--
+	}
+	t.run(-1)
+	t.mutex.Unlock()
+
+	if t.done != nil {
+		select {
+		case t.done <- src:
+		case <-t.stopped:
+		}
+	}
+}
+
+// stop releases the operations that are waiting for their completion to be
+// received. It is called when nothing receives completions anymore.
+func (t *asyncTracker) stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopped)
+	})
+}
+
+// receive waits for a completion and returns the element that launched the
+// completed operation. It returns false when the given context is done
+// before.
+func (t *asyncTracker) receive(ctx context.Context) (UI, bool) {
+	select {
+	case src := <-t.done:
+		t.received(1)
+		return src, true
+
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+func (t *asyncTracker) received(n int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.count -= n
+}
+
+// wait waits for the running operations to be done, whether their completion
+// is received or not. It returns false when the given context is done before.
+func (t *asyncTracker) wait(ctx context.Context) bool {
+	t.mutex.Lock()
+	idle := t.idleChan
+	t.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return true
+
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// run adds n to the number of running operations. The idle channel is closed
+// when no operation is running anymore and replaced when operations run
+// again. It must be called with the mutex locked.
+func (t *asyncTracker) run(n int) {
+	wasIdle := t.running == 0
+	t.running += n
+
+	switch {
+	case t.running == 0 && !wasIdle:
+		close(t.idleChan)
+
+	case t.running != 0 && wasIdle:
+		t.idleChan = make(chan struct{})
+	}
+}
+
+func (t *asyncTracker) idle() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.count == 0
+}
+
+func (t *asyncTracker) sources() []UI {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sources := make([]UI, 0, len(t.pending))
+	for src := range t.pending {
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// unfinished returns the elements that launched operations that are either
+// still running or that finished late.
+func (t *asyncTracker) unfinished() []UI {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sources := make([]UI, 0, len(t.pending)+len(t.late))
+	for src := range t.pending {
+		sources = append(sources, src)
+	}
+	for src := range t.late {
+		if _, ok := t.pending[src]; !ok {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+func (t *asyncTracker) drain(sources []UI) []UI {
+	for {
+		select {
+		case src := <-t.done:
+			t.received(1)
+			sources = append(sources, src)
+
+		default:
+			return sources
+		}
+	}
+}
+
+// pageStreamer writes a server-side pre-rendered page while its asynchronous
+// operations are running.
+//
+// The components that are waiting for asynchronous operations are written
+// between comment markers. Once their operations are done, their updated
+// content is written at the end of the body within a template element, along
+// with a script that moves it between the markers.
+type pageStreamer struct {
+	w             io.Writer
+	disp          *engine
+	ids           map[Composer]int
+	scriptWritten bool
+	nextID        int
+	nonce         string
+}
+
+func (h *Handler) streamPage(ctx context.Context, w http.ResponseWriter, r *http.Request, disp *engine, page *requestPage, body HTMLBody) bool {
+	s := pageStreamer{
+		w:     w,
+		disp:  disp,
+		ids:   make(map[Composer]int),
+		nonce: page.nonce,
+	}
+
+	disp.consumeReady()
+
+	if redirectPage(w, r, disp, page) {
+		return true
+	}
+
+	root := body.getChildren()[0]
+	if c, ok := root.(Composer); ok {
+		s.mark(c)
+	}
+	s.markPending(root)
+
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n")
+	PrintHTML(&b, h.pageHTML(page, body))
+
+	document := b.String()
+	end := strings.LastIndex(document, "</body>")
+	if end < 0 {
+		end = len(document)
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	writePageHeader(w, page)
+	io.WriteString(w, document[:end])
+	s.flush()
+
+	completed := s.stream(ctx)
+	if completed {
+		s.writeChunks(disp.asyncs.drain(nil))
+	}
+
+	// States are written once streamed since they can be set by asynchronous
+	// operations.
+	if states := disp.states.hydratedStates(); len(states) != 0 {
+		io.WriteString(w, jsonScript(hydratedStatesID, states))
+	}
+	io.WriteString(w, document[end:])
+	s.flush()
+	return completed
+}
+
+// stream writes the content of the components that are done with their
+// asynchronous operations, until all operations are done. It returns false
+// when the given context is done before.
+func (s *pageStreamer) stream(ctx context.Context) bool {
+	for !s.disp.asyncs.idle() {
+		src, ok := s.disp.asyncs.receive(ctx)
+		if !ok {
+			return false
+		}
+
+		sources := s.disp.asyncs.drain([]UI{src})
+		s.disp.consumeReady()
+		s.writeChunks(sources)
+	}
+
+	return preRender(ctx, s.disp)
+}
+
+func (s *pageStreamer) mark(c Composer) int {
+	id, ok := s.ids[c]
+	if !ok {
+		s.nextID++
+		id = s.nextID
+		s.ids[c] = id
+		c.setStreamID(id)
+	}
+	return id
+}
+
+// markPending marks the components that are within the given element and
+// that are waiting for asynchronous operations.
+func (s *pageStreamer) markPending(root UI) {
+	for _, src := range s.disp.asyncs.sources() {
+		c := nearestComponent(src)
+		if c == nil || !c.Mounted() || !isDescendant(c, root) {
+			continue
+		}
+		s.mark(c)
+	}
+}
+
+// target returns the nearest marked component that contains the given
+// element.
+func (s *pageStreamer) target(src UI) Composer {
+	if src == nil {
+		src = s.disp.Body.getChildren()[0]
+	}
+
+	for n := src; n != nil; n = n.getParent() {
+		if c, ok := n.(Composer); ok && c.Mounted() && s.ids[c] != 0 {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *pageStreamer) writeChunks(sources []UI) {
+	targets := make(map[Composer]bool)
+	for _, src := range sources {
+		if c := s.target(src); c != nil {
+			targets[c] = true
+		}
+	}
+
+	chunks := make([]Composer, 0, len(targets))
+	for c := range targets {
+		if !hasAncestorIn(c, targets) {
+			chunks = append(chunks, c)
+		}
+	}
+	if len(chunks) == 0 {
+		return
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		return s.ids[chunks[i]] < s.ids[chunks[j]]
+	})
+
+	if !s.scriptWritten {
+		io.WriteString(s.w, s.scriptTag())
+		io.WriteString(s.w, streamScript)
+		io.WriteString(s.w, "</script>\n")
+		s.scriptWritten = true
+	}
+
+	for _, c := range chunks {
+		root := c.getChildren()[0]
+		s.markPending(root)
+
+		id := s.ids[c]
+		fmt.Fprintf(s.w, `<template data-goapp-stream="%d">`, id)
+		root.html(s.w)
+		fmt.Fprintf(s.w, "</template>%sgoappResolveStream(%d)</script>\n", s.scriptTag(), id)
+	}
+	s.flush()
+}
+
+// scriptTag returns the opening tag of the inline scripts, with the page
+// Content-Security-Policy nonce when there is one.
+func (s *pageStreamer) scriptTag() string {
+	if s.nonce == "" {
+		return "<script>"
+	}
+	return `<script nonce="` + s.nonce + `">`
+}
+
+func (s *pageStreamer) flush() {
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func nearestComponent(n UI) Composer {
+	for ; n != nil; n = n.getParent() {
+		if c, ok := n.(Composer); ok {
+			return c
+		}
+	}
+	return nil
+}
+
+func isDescendant(n, root UI) bool {
+	for ; n != nil; n = n.getParent() {
+		if n == root {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAncestorIn(c Composer, set map[Composer]bool) bool {
+	for n := c.getParent(); n != nil; n = n.getParent() {
+		if p, ok := n.(Composer); ok && set[p] {
+			return true
+		}
+	}
+	return false
+}
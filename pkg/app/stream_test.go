@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncTracker(t *testing.T) {
+	t.Run("completions are counted until received", func(t *testing.T) {
+		tracker := newAsyncTracker(true)
+		for i := 0; i < 4; i++ {
+			tracker.start(nil)
+		}
+		for i := 0; i < 4; i++ {
+			go tracker.finish(nil, false)
+		}
+
+		received := 0
+		for !tracker.idle() {
+			_, ok := tracker.receive(context.Background())
+			require.True(t, ok)
+			received++
+			received += len(tracker.drain(nil))
+		}
+		require.Equal(t, 4, received)
+	})
+
+	t.Run("receive returns on context done", func(t *testing.T) {
+		tracker := newAsyncTracker(true)
+		tracker.start(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, ok := tracker.receive(ctx)
+		require.False(t, ok)
+		require.False(t, tracker.idle())
+	})
+
+	t.Run("wait returns when operations are done", func(t *testing.T) {
+		tracker := newAsyncTracker(true)
+		require.True(t, tracker.wait(context.Background()))
+
+		tracker.start(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.False(t, tracker.wait(ctx))
+
+		tracker.finish(nil, false)
+		require.True(t, tracker.wait(context.Background()))
+		require.False(t, tracker.idle())
+	})
+
+	t.Run("finish does not block once stopped", func(t *testing.T) {
+		tracker := newAsyncTracker(true)
+		tracker.done = make(chan UI)
+		tracker.start(nil)
+		tracker.stop()
+
+		finished := make(chan struct{})
+		go func() {
+			tracker.finish(nil, true)
+			close(finished)
+		}()
+
+		select {
+		case <-finished:
+		case <-time.After(time.Second):
+			require.Fail(t, "finish is blocked")
+		}
+	})
+
+	t.Run("completions are not reported", func(t *testing.T) {
+		tracker := newAsyncTracker(false)
+		tracker.start(nil)
+		tracker.finish(nil, false)
+		require.True(t, tracker.idle())
+	})
+}
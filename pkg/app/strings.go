@@ -130,6 +130,19 @@ func jsonString(v any) string {
 	return string(b)
 }
 
+// jsonScript returns a script element with the given id that contains the
+// given value encoded to JSON, in order to be read by the client. Encoded HTML
+// characters are escaped, which prevents the value from closing the element.
+func jsonScript(id string, v any) string {
+	var b strings.Builder
+	b.WriteString(`<script id="`)
+	b.WriteString(id)
+	b.WriteString(`" type="application/json">`)
+	b.WriteString(jsonString(v))
+	b.WriteString("</script>")
+	return b.String()
+}
+
 // Formats a string with the given format and values.
 // It uses fmt.Sprintf when len(v) != 0.
 //
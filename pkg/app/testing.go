@@ -28,6 +28,10 @@ type TestUIDescriptor struct {
 	// handlers are set.
 	//
 	// Components have their exported field values compared.
+	//
+	// Fragments have their key compared. Their children are matched with Path
+	// and must not be set on the expected fragment. Outlets do not have any
+	// value to compare and must be expected with Outlet().
 	Expected UI
 }
 
@@ -137,6 +141,15 @@ func TestMatch(tree UI, d TestUIDescriptor) error {
 	case RawHTML:
 		return matchRaw(tree, d)
 
+	case Fragment:
+		return matchFragment(tree, d)
+
+	case OutletElem:
+		return matchOutlet(tree, d)
+
+	case PortalElem:
+		return matchPortal(tree, d)
+
 	default:
 		return errors.New("the UI element is not matching the descriptor").
 			WithTag("reason", "unavailable matching for the kind").
@@ -283,3 +296,46 @@ func matchRaw(n UI, d TestUIDescriptor) error {
 
 	return nil
 }
+
+func matchFragment(n UI, d TestUIDescriptor) error {
+	if len(d.Expected.getChildren()) != 0 {
+		return errors.New("the fragment is not matching the descriptor").
+			WithTag("name", n.name()).
+			WithTag("reason", "expected fragment children are not compared, match them with the descriptor path").
+			WithTag("expected-children-count", len(d.Expected.getChildren()))
+	}
+
+	if k := d.Expected.getKey(); k != nil && k != n.getKey() {
+		return errors.New("the fragment is not matching the descriptor").
+			WithTag("name", n.name()).
+			WithTag("reason", "unexpected key").
+			WithTag("expected-key", k).
+			WithTag("current-key", n.getKey())
+	}
+
+	return nil
+}
+
+func matchOutlet(n UI, d TestUIDescriptor) error {
+	if len(d.Expected.getChildren()) != 0 {
+		return errors.New("the outlet is not matching the descriptor").
+			WithTag("name", n.name()).
+			WithTag("reason", "expected outlet child is not compared, match it with the descriptor path")
+	}
+	return nil
+}
+
+func matchPortal(n UI, d TestUIDescriptor) error {
+	a := n.(*portal)
+	b := d.Expected.(*portal)
+
+	if a.targetID != b.targetID {
+		return errors.New("the portal is not matching with the descriptor").
+			WithTag("name", n.name()).
+			WithTag("reason", "unexpected target id").
+			WithTag("expected-target-id", b.targetID).
+			WithTag("current-target-id", a.targetID)
+	}
+
+	return nil
+}
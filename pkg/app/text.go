@@ -65,6 +65,10 @@ func (t *text) getEventHandlers() eventHandlers {
 	return nil
 }
 
+func (t *text) getKey() any {
+	return nil
+}
+
 func (t *text) getParent() UI {
 	return t.parentElem
 }
@@ -91,6 +95,10 @@ func (t *text) mount(d Dispatcher) error {
 	return nil
 }
 
+func (t *text) hydrate(d Dispatcher, c *hydrationCursor) error {
+	return t.mount(d)
+}
+
 func (t *text) dismount() {
 	t.jsvalue = nil
 }
@@ -10,10 +10,9 @@ import (
 )
 
 const (
-	appJS        = ""
-	appWorkerJS  = ""
-	manifestJSON = ""
-	appCSS       = ""
+	appJS       = ""
+	appWorkerJS = ""
+	appCSS      = ""
 )
 
 var (